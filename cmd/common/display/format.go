@@ -5,6 +5,7 @@ package display
 import (
 	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,7 +16,7 @@ import (
 // BindOutputFormatFlag binds the output format flag to the command.
 // This should be added on the root command.
 func BindOutputFormatFlag(cmd *cobra.Command) {
-	cmd.PersistentFlags().String("output", defaultOutputFormat.string(), "the format for command output - either 'text' or 'json'")
+	cmd.PersistentFlags().String("output", defaultOutputFormat.string(), "the format for command output - either 'text', 'json', or 'csv' (query results only)")
 }
 
 // BindSilenceFlag binds the silence flag to the passed command.
@@ -45,7 +46,7 @@ func (o OutputFormat) string() string {
 // Valid returns true if the output format is valid.
 func (o OutputFormat) valid() bool {
 	switch o {
-	case outputFormatText, outputFormatJSON:
+	case outputFormatText, outputFormatJSON, outputFormatCSV:
 		return true
 	default:
 		return false
@@ -55,6 +56,7 @@ func (o OutputFormat) valid() bool {
 const (
 	outputFormatText OutputFormat = "text"
 	outputFormatJSON OutputFormat = "json"
+	outputFormatCSV  OutputFormat = "csv"
 
 	defaultOutputFormat = outputFormatText
 )
@@ -78,6 +80,12 @@ type MsgFormatter interface {
 	encoding.TextMarshaler
 }
 
+// CSVMarshaler may be implemented by a MsgFormatter that has a tabular form,
+// such as query results, to support the csv output format.
+type CSVMarshaler interface {
+	MarshalCSV() ([]byte, error)
+}
+
 type wrappedMsg struct {
 	Result MsgFormatter `json:"result"`
 	Error  string       `json:"error"`
@@ -111,6 +119,28 @@ func (w *wrappedMsg) printText(stdout io.Writer, stderr io.Writer) error {
 	return nil
 }
 
+// printCSV prints the wrappedMsg in csv format. It prints to stdout if
+// `w.Error` is empty, otherwise it prints to stderr like printText.
+func (w *wrappedMsg) printCSV(stdout io.Writer, stderr io.Writer) error {
+	if w.Error != "" {
+		fmt.Fprintln(stderr, w.Error)
+		return nil
+	}
+
+	csvMsg, ok := w.Result.(CSVMarshaler)
+	if !ok {
+		return errors.New("csv output is not supported for this command")
+	}
+
+	msg, err := csvMsg.MarshalCSV()
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(msg)
+	return err
+}
+
 // wrapMsg wraps response and error in a wrappedMsg struct.
 func wrapMsg(msg MsgFormatter, err error) *wrappedMsg {
 	if err != nil {
@@ -132,6 +162,8 @@ func prettyPrint(msg *wrappedMsg, format OutputFormat, stdout io.Writer, stderr
 		return msg.printJson(stdout, stderr)
 	case outputFormatText:
 		return msg.printText(stdout, stderr)
+	case outputFormatCSV:
+		return msg.printCSV(stdout, stderr)
 	default:
 		return fmt.Errorf("invalid output format: %s", format)
 	}
@@ -69,3 +69,35 @@ func Example_wrappedMsg_json_withError() {
 	//   "error": "an error"
 	// }
 }
+
+type demoCSV struct {
+	demoFormat
+}
+
+func (d *demoCSV) MarshalCSV() ([]byte, error) {
+	return []byte("name\n" + string(d.data) + "\n"), nil
+}
+
+func Example_wrappedMsg_csv() {
+	msg := wrapMsg(&demoCSV{demoFormat{data: []byte("demo")}}, nil)
+	prettyPrint(msg, "csv", os.Stdout, os.Stderr)
+	// Output:
+	// name
+	// demo
+}
+
+func Test_wrappedMsg_csv_unsupported(t *testing.T) {
+	var stderr bytes.Buffer
+	var stdout bytes.Buffer
+
+	msg := wrapMsg(&demoFormat{data: []byte("demo")}, nil)
+	err := prettyPrint(msg, "csv", &stdout, &stderr)
+	assert.Error(t, err)
+	assert.Empty(t, stdout.String())
+
+	msg = wrapMsg(&demoFormat{data: []byte("demo")}, errors.New("an error"))
+	err = prettyPrint(msg, "csv", &stdout, &stderr)
+	assert.NoError(t, err)
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "an error\n", stderr.String())
+}
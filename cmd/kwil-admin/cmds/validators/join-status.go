@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/cmd/kwil-admin/cmds/common"
@@ -85,14 +84,8 @@ func (r *respValJoinStatus) MarshalJSON() ([]byte, error) {
 }
 
 func (r *respValJoinStatus) MarshalText() ([]byte, error) {
-	approved := 0
-	for _, a := range r.Data.Approved {
-		if a {
-			approved++
-		}
-	}
-
-	needed := int(math.Ceil(float64(len(r.Data.Board)) * 2 / 3))
+	approved, _ := r.Data.ApprovalStatus()
+	needed := r.Data.ApprovalsNeeded(2.0 / 3)
 
 	var msg bytes.Buffer
 	msg.WriteString(fmt.Sprintf("Candidate: %x\n", r.Data.Candidate))
@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/cmd/kwil-admin/cmds/common"
@@ -65,7 +64,7 @@ func (r *respJoinList) MarshalText() ([]byte, error) {
 		return msg.Bytes(), nil
 	}
 
-	needed := int(math.Ceil(float64(len(r.Joins[0].Board)) * 2 / 3))
+	needed := r.Joins[0].ApprovalsNeeded(2.0 / 3)
 
 	approvalTerm := "approvals"
 	if needed == 1 {
@@ -78,13 +77,7 @@ func (r *respJoinList) MarshalText() ([]byte, error) {
 	msg.WriteString("------------------------------------------------------------------+-------+-----------+------------")
 	//ref spacing:    22cbbb666c26b2c1f42502df72c32de4d521138a1a2c96121d417a2f341a759c | 1     | 100	   | 100
 	for _, j := range r.Joins {
-		approvals := 0
-		for _, a := range j.Approved {
-			if a {
-				approvals++
-			}
-		}
-
+		approvals, _ := j.ApprovalStatus()
 		msg.WriteString(fmt.Sprintf("\n %s | % 5d | % 9d | %d", hex.EncodeToString(j.Candidate), j.Power, approvals, j.ExpiresAt))
 
 	}
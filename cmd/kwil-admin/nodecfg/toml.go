@@ -74,6 +74,8 @@ const defaultConfigTemplate = `
 
 [log]
 
+# The log levels are applied to a running node when this file is changed.
+
 # Output level for logging, default is "info". Other options are "debug", "error", "warn", "trace"
 level = "{{ .Logging.Level }}"
 
@@ -274,6 +276,7 @@ external_address = "{{ .ChainCfg.P2P.ExternalAddress }}"
 # Comma separated list of nodes to keep persistent connections to (used for bootstrapping)
 # Nodes should be identified as id@host:port, where id is the hex encoded CometBFT address.
 # Example: "d128266b8b9f64c313de466cf29e0a6182dba54d@172.10.100.2:26656,9440f4a8059cf7ff31454973c4f9c68de65fe526@172.10.100.3:26656"
+# Changes are applied to a running node, which dials any new peers.
 persistent_peers = "{{ .ChainCfg.P2P.PersistentPeers }}"
 
 # Set true for strict address routability rules
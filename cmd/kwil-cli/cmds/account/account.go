@@ -6,7 +6,6 @@ import (
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
-	"github.com/kwilteam/kwil-db/core/crypto/auth"
 
 	"github.com/spf13/cobra"
 )
@@ -22,11 +21,11 @@ var idCmd = &cobra.Command{
 			return display.PrintErr(cmd, err)
 		}
 
-		if conf.PrivateKey == nil {
+		signer := conf.Signer()
+		if signer == nil {
 			return display.PrintErr(cmd, errors.New("no private key configured"))
 		}
 
-		signer := &auth.EthPersonalSigner{Key: *conf.PrivateKey}
 		return display.PrintCmd(cmd, display.RespString(hex.EncodeToString(signer.Identity())))
 	},
 }
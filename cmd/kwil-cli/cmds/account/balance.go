@@ -16,7 +16,8 @@ import (
 )
 
 func balanceCmd() *cobra.Command {
-	var pending bool
+	var pending, all bool
+	var decimals uint8
 	cmd := &cobra.Command{
 		Use:   "balance",
 		Short: "Gets an account's balance and nonce",
@@ -42,6 +43,13 @@ func balanceCmd() *cobra.Command {
 						return display.PrintErr(cmd, errors.New("empty account ID"))
 					}
 				}
+				if all {
+					accts, err := cl.GetAccountStates(ctx, acctID)
+					if err != nil {
+						return display.PrintErr(cmd, fmt.Errorf("get account failed: %w", err))
+					}
+					return display.PrintCmd(cmd, &respAccountStates{accts, decimals})
+				}
 				status := types.AccountStatusLatest
 				if pending {
 					status = types.AccountStatusPending
@@ -53,7 +61,7 @@ func balanceCmd() *cobra.Command {
 				// NOTE: empty acct.Identifier means it doesn't even have a record
 				// on the network. Perhaps we convey that to the caller? Their
 				// balance is zero regardless, assuming it's the correct acct ID.
-				resp := (*respAccount)(acct)
+				resp := &respAccount{acct, decimals}
 				return display.PrintCmd(cmd, resp)
 			})
 
@@ -61,6 +69,9 @@ func balanceCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&pending, "pending", false, "reflect pending updates from mempool (default is confirmed only)")
+	cmd.Flags().BoolVar(&all, "all", false, "show both the confirmed and pending balance and nonce")
+	cmd.MarkFlagsMutuallyExclusive("pending", "all")
+	cmd.Flags().Uint8Var(&decimals, "decimals", 0, "number of decimal places of the token, to display the balance in whole tokens (default shows the smallest unit)")
 
 	return cmd
 }
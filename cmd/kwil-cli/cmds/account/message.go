@@ -8,17 +8,26 @@ import (
 	"github.com/kwilteam/kwil-db/core/types"
 )
 
-type respAccount types.Account
+type respAccount struct {
+	*types.Account
+	decimals uint8 // for the formatted balance, if non-zero
+}
 
 func (r *respAccount) MarshalJSON() ([]byte, error) {
+	var formatted string
+	if r.decimals > 0 {
+		formatted = types.FormatBalance(r.Balance, r.decimals)
+	}
 	return json.Marshal(struct {
-		Identifier string `json:"identifier"`
-		Balance    string `json:"balance"`
-		Nonce      int64  `json:"nonce"`
+		Identifier       string `json:"identifier"`
+		Balance          string `json:"balance"`
+		FormattedBalance string `json:"formatted_balance,omitempty"`
+		Nonce            int64  `json:"nonce"`
 	}{
-		Identifier: hex.EncodeToString(r.Identifier),
-		Balance:    r.Balance.String(),
-		Nonce:      r.Nonce,
+		Identifier:       hex.EncodeToString(r.Identifier),
+		Balance:          r.Balance.String(),
+		FormattedBalance: formatted,
+		Nonce:            r.Nonce,
 	})
 }
 
@@ -26,7 +35,34 @@ func (r *respAccount) MarshalText() ([]byte, error) {
 	msg := fmt.Sprintf(`Account ID: %x
 Balance: %s
 Nonce: %d
-`, r.Identifier, r.Balance, r.Nonce)
+`, r.Identifier, types.FormatBalance(r.Balance, r.decimals), r.Nonce)
+
+	return []byte(msg), nil
+}
+
+type respAccountStates struct {
+	*types.AccountStates
+	decimals uint8
+}
+
+func (r *respAccountStates) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Latest  *respAccount `json:"latest"`
+		Pending *respAccount `json:"pending"`
+	}{
+		Latest:  &respAccount{r.Latest, r.decimals},
+		Pending: &respAccount{r.Pending, r.decimals},
+	})
+}
+
+func (r *respAccountStates) MarshalText() ([]byte, error) {
+	msg := fmt.Sprintf(`Account ID: %x
+Balance: %s
+Nonce: %d
+Pending balance: %s
+Pending nonce: %d
+`, r.Latest.Identifier, types.FormatBalance(r.Latest.Balance, r.decimals), r.Latest.Nonce,
+		types.FormatBalance(r.Pending.Balance, r.decimals), r.Pending.Nonce)
 
 	return []byte(msg), nil
 }
@@ -3,19 +3,20 @@ package account
 import (
 	"context"
 	"encoding/hex"
-	"errors"
 	"fmt"
-	"math/big"
 	"time"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/common"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
+	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
 	"github.com/spf13/cobra"
 )
 
 func transferCmd() *cobra.Command {
+	var decimals uint8
+	var confirmations int64
 	cmd := &cobra.Command{
 		Use:   "transfer <recipient> <amount>",
 		Short: "Transfer value to an account",
@@ -27,9 +28,9 @@ func transferCmd() *cobra.Command {
 			if err != nil {
 				return display.PrintErr(cmd, err)
 			}
-			amount, ok := big.NewInt(0).SetString(amt, 10)
-			if !ok {
-				return display.PrintErr(cmd, errors.New("invalid decimal amount"))
+			amount, err := types.ParseBalance(amt, decimals)
+			if err != nil {
+				return display.PrintErr(cmd, fmt.Errorf("invalid decimal amount: %w", err))
 			}
 
 			return common.DialClient(cmd.Context(), cmd, 0, func(ctx context.Context, cl clientType.Client, conf *config.KwilCliConfig) error {
@@ -38,6 +39,15 @@ func transferCmd() *cobra.Command {
 				if err != nil {
 					return display.PrintErr(cmd, fmt.Errorf("transfer failed: %w", err))
 				}
+				// If waiting for confirmations, wait for inclusion and then for
+				// the chain to advance.
+				if len(txHash) != 0 && confirmations > 0 {
+					resp, err := cl.WaitConfirmations(ctx, txHash, confirmations, time.Second)
+					if err != nil {
+						return display.PrintErr(cmd, fmt.Errorf("waiting for confirmations failed: %w", err))
+					}
+					return display.PrintCmd(cmd, display.NewTxHashAndExecResponse(resp))
+				}
 				// If sycnBcast, and we have a txHash (error or not), do a query-tx.
 				if len(txHash) != 0 && syncBcast {
 					time.Sleep(500 * time.Millisecond) // otherwise it says not found at first
@@ -52,5 +62,8 @@ func transferCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Uint8Var(&decimals, "decimals", 0, "number of decimal places of the token, if the amount is in whole tokens (default is the smallest unit)")
+	cmd.Flags().Int64Var(&confirmations, "confirmations", 0, "wait until the transaction is included in a block and this many more blocks are committed")
+
 	return cmd
 }
@@ -43,8 +43,8 @@ func DialClient(ctx context.Context, cmd *cobra.Command, flags uint8, fn RoundTr
 	needPrivateKey := flags&WithoutPrivateKey == 0
 
 	clientConfig := clientType.Options{}
-	if conf.PrivateKey != nil {
-		clientConfig.Signer = &auth.EthPersonalSigner{Key: *conf.PrivateKey}
+	if signer := conf.Signer(); signer != nil {
+		clientConfig.Signer = signer
 		if needPrivateKey { // only check chain ID if signing something
 			clientConfig.ChainID = conf.ChainID
 		}
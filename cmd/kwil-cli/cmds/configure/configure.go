@@ -2,6 +2,7 @@ package configure
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	common "github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/common/prompt"
@@ -17,6 +18,7 @@ This command will prompt you for the following settings:
 
 - Kwil RPC provider URL: the RPC URL of the Kwil node you wish to connect to.
 - Kwil Chain ID: the chain ID of the Kwil node you wish to connect to.  If left empty, the Kwil node will provide this value.
+- Key Type: the type of the private key, either secp256k1 (the default) or ed25519.
 - Private Key: the private key to use for signing transactions.  If left empty, the Kwil CLI will not sign transactions.`
 
 var configureExample = `kwil-cli configure`
@@ -95,10 +97,46 @@ func promptChainID(conf *config.KwilCliConfig) error {
 	return nil
 }
 
+// promptKeyType asks for the type of the private key, defaulting to the type of
+// the configured key, or secp256k1 if there is none.
+func promptKeyType(conf *config.KwilCliConfig) (string, error) {
+	defaultKeyType := config.KeyTypeSecp256k1
+	if conf.Ed25519PrivateKey != nil {
+		defaultKeyType = config.KeyTypeEd25519
+	}
+	prompt := &common.Prompter{
+		Label:   fmt.Sprintf("Key Type (%s or %s)", config.KeyTypeSecp256k1, config.KeyTypeEd25519),
+		Default: defaultKeyType,
+	}
+	res, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	switch keyType := strings.ToLower(strings.TrimSpace(res)); keyType {
+	case "":
+		return defaultKeyType, nil
+	case config.KeyTypeSecp256k1, config.KeyTypeEd25519:
+		return keyType, nil
+	default:
+		fmt.Printf("unsupported key type %q\n", res)
+		return promptKeyType(conf)
+	}
+}
+
 func promptPrivateKey(conf *config.KwilCliConfig) error {
+	keyType, err := promptKeyType(conf)
+	if err != nil {
+		return err
+	}
+	isEd25519 := keyType == config.KeyTypeEd25519
+
+	// Offer the configured key as the default only if it is the chosen type.
 	var defaultPrivKeyHex string
-	if conf.PrivateKey != nil {
+	if conf.PrivateKey != nil && !isEd25519 {
 		defaultPrivKeyHex = conf.PrivateKey.Hex()
+	} else if conf.Ed25519PrivateKey != nil && isEd25519 {
+		defaultPrivKeyHex = conf.Ed25519PrivateKey.Hex()
 	}
 	prompt := &common.Prompter{
 		Label:   "Private Key",
@@ -111,10 +149,17 @@ func promptPrivateKey(conf *config.KwilCliConfig) error {
 
 	if res == "" {
 		conf.PrivateKey = nil
+		conf.Ed25519PrivateKey = nil
 		return nil
 	}
 
-	pk, err := crypto.Secp256k1PrivateKeyFromHex(res)
+	var secpKey *crypto.Secp256k1PrivateKey
+	var edKey *crypto.Ed25519PrivateKey
+	if isEd25519 {
+		edKey, err = crypto.Ed25519PrivateKeyFromHex(res)
+	} else {
+		secpKey, err = crypto.Secp256k1PrivateKeyFromHex(res)
+	}
 	if err != nil {
 		fmt.Printf("invalid private key: %v\n", err)
 		promptAskAgain := &common.Prompter{
@@ -132,7 +177,8 @@ func promptPrivateKey(conf *config.KwilCliConfig) error {
 		return nil
 	}
 
-	conf.PrivateKey = pk
+	conf.PrivateKey = secpKey
+	conf.Ed25519PrivateKey = edKey
 
 	return nil
 }
@@ -70,7 +70,12 @@ func callCmd() *cobra.Command {
 					return display.PrintErr(cmd, fmt.Errorf("error getting inputs: %w", err))
 				}
 
-				tuples, err := buildExecutionInputs(ctx, clnt, dbid, lowerName, inputs)
+				schema, err := clnt.GetSchema(ctx, dbid)
+				if err != nil {
+					return display.PrintErr(cmd, fmt.Errorf("error getting schema: %w", err))
+				}
+
+				tuples, err := buildSchemaInputs(schema, lowerName, inputs)
 				if err != nil {
 					return display.PrintErr(cmd, fmt.Errorf("error creating action inputs: %w", err))
 				}
@@ -88,7 +93,8 @@ func callCmd() *cobra.Command {
 					data = &clientType.Records{}
 				}
 
-				return display.PrintCmd(cmd, &respRelations{Data: data})
+				cols, colTypes := procedureColumns(schema, lowerName)
+				return display.PrintCmd(cmd, &respRelations{Data: data, Columns: cols, Types: colTypes})
 			})
 		},
 	}
@@ -111,6 +117,12 @@ func buildExecutionInputs(ctx context.Context, client clientType.Client, dbid st
 		return nil, fmt.Errorf("error getting schema: %w", err)
 	}
 
+	return buildSchemaInputs(schema, proc, inputs)
+}
+
+// buildSchemaInputs is like buildExecutionInputs, but with a schema that has
+// already been retrieved.
+func buildSchemaInputs(schema *types.Schema, proc string, inputs []map[string]string) ([][]any, error) {
 	for _, a := range schema.Actions {
 		if strings.EqualFold(a.Name, proc) {
 			return buildActionInputs(a, inputs)
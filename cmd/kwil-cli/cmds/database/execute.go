@@ -2,7 +2,11 @@ package database
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/common"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
 
 	"github.com/spf13/cobra"
 )
@@ -25,17 +30,29 @@ For example, for action ` + "`" + `get_user($username)` + "`" + `, you would spe
 
 You can either specify the database to execute this against with the ` + "`" + `--name` + "`" + ` and ` + "`" + `--owner` + "`" + `
 flags, or you can specify the database by passing the database id with the ` + "`" + `--dbid` + "`" + ` flag.  If a ` + "`" + `--name` + "`" + `
-flag is passed and no ` + "`" + `--owner` + "`" + ` flag is passed, the owner will be inferred from your configured wallet.`
+flag is passed and no ` + "`" + `--owner` + "`" + ` flag is passed, the owner will be inferred from your configured wallet.
+
+To execute many transactions with one command, use the ` + "`" + `--batch-file` + "`" + ` flag instead of ` + "`" + `--action` + "`" + ` and arguments.
+Each line of the file, or of stdin if the path is "-", is one transaction: the action name followed by its
+parameters, separated by spaces. A parameter containing spaces may be double quoted, and lines starting
+with # are ignored. All lines are checked before any transaction is broadcast, and the hashes of the
+transactions are returned in the order of the lines.`
 
 	executeExample = `# Executing the ` + "`" + `create_user($username, $age)` + "`" + ` action on the "mydb" database
 kwil-cli database execute username:satoshi age:32 --action create_user --name mydb --owner 0x9228624C3185FCBcf24c1c9dB76D8Bef5f5DAd64
 
 # Executing the ` + "`" + `create_user($username, $age)` + "`" + ` action on a database using a dbid
-kwil-cli database execute username:satoshi age:32 --action create_user --dbid 0x9228624C3185FCBcf24c1c9dB76D8Bef5f5DAd64`
+kwil-cli database execute username:satoshi age:32 --action create_user --dbid 0x9228624C3185FCBcf24c1c9dB76D8Bef5f5DAd64
+
+# Executing one transaction for each line of a file, which has the action name
+# followed by the parameters, e.g. create_user username:satoshi "bio:hello world"
+kwil-cli database execute --batch-file ./txs.txt --name mydb`
 )
 
 func executeCmd() *cobra.Command {
 	var actionName string
+	var confirmations int64
+	var batchFile string
 
 	cmd := &cobra.Command{
 		Use:     "execute <parameter_1:value_1> <parameter_2:value_2> ...",
@@ -49,6 +66,19 @@ func executeCmd() *cobra.Command {
 					return display.PrintErr(cmd, fmt.Errorf("target database not properly specified: %w", err))
 				}
 
+				if batchFile != "" {
+					if actionName != "" || len(args) > 0 {
+						return display.PrintErr(cmd, errors.New("--batch-file cannot be used with --action or parameter arguments"))
+					}
+					if confirmations > 0 {
+						return display.PrintErr(cmd, errors.New("--batch-file cannot be used with --confirmations"))
+					}
+					return executeBatchFile(ctx, cmd, cl, dbId, batchFile)
+				}
+				if actionName == "" {
+					return display.PrintErr(cmd, errors.New("an action name is required"))
+				}
+
 				lowerName := strings.ToLower(actionName)
 
 				parsedArgs, err := parseInputs(args)
@@ -68,6 +98,15 @@ func executeCmd() *cobra.Command {
 				if err != nil {
 					return display.PrintErr(cmd, fmt.Errorf("error executing database: %w", err))
 				}
+				// If waiting for confirmations, wait for inclusion and then for
+				// the chain to advance.
+				if len(txHash) != 0 && confirmations > 0 {
+					resp, err := cl.WaitConfirmations(ctx, txHash, confirmations, time.Second)
+					if err != nil {
+						return display.PrintErr(cmd, fmt.Errorf("waiting for confirmations failed: %w", err))
+					}
+					return display.PrintCmd(cmd, display.NewTxHashAndExecResponse(resp))
+				}
 				// If sycnBcast, and we have a txHash (error or not), do a query-tx.
 				if len(txHash) != 0 && syncBcast {
 					time.Sleep(500 * time.Millisecond) // otherwise it says not found at first
@@ -86,12 +125,131 @@ func executeCmd() *cobra.Command {
 	cmd.Flags().StringP(ownerFlag, "o", "", "the target database owner")
 	cmd.Flags().StringP(dbidFlag, "i", "", "the target database id")
 
-	cmd.Flags().StringVarP(&actionName, actionNameFlag, "a", "", "the target action name (required)")
+	cmd.Flags().StringVarP(&actionName, actionNameFlag, "a", "", "the target action name (required unless using --batch-file)")
+	cmd.Flags().StringVar(&batchFile, "batch-file", "", "path to a file with one action and its parameters per line, or - for stdin")
+	cmd.Flags().Int64Var(&confirmations, "confirmations", 0, "wait until the transaction is included in a block and this many more blocks are committed (not with --batch-file)")
 
-	cmd.MarkFlagRequired(actionNameFlag)
 	return cmd
 }
 
+// executeBatchFile executes the transactions in a batch file, or stdin if the
+// path is "-", and prints their hashes.
+func executeBatchFile(ctx context.Context, cmd *cobra.Command, cl clientType.Client, dbid, path string) error {
+	r := cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return display.PrintErr(cmd, fmt.Errorf("error opening batch file: %w", err))
+		}
+		defer file.Close()
+		r = file
+	}
+
+	txHashes, err := executeBatch(ctx, cl, dbid, r, nonceOverride, syncBcast)
+	if err != nil {
+		if len(txHashes) > 0 {
+			err = fmt.Errorf("%w (broadcast before the error: %x)", err, txHashes)
+		}
+		return display.PrintErr(cmd, err)
+	}
+	return display.PrintCmd(cmd, respTxHashes(txHashes))
+}
+
+// batchLine is a line of a batch file.
+type batchLine struct {
+	num    int
+	action string
+	args   []string
+}
+
+// readBatchLines reads the lines of a batch file. Each line is an action name
+// followed by its parameters, separated by spaces, and fields with spaces may
+// be double quoted. Blank lines and lines starting with # are skipped.
+func readBatchLines(r io.Reader) ([]*batchLine, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = ' '
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+
+	var lines []*batchLine
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		num, _ := cr.FieldPos(0)
+
+		var fields []string
+		for _, field := range record {
+			if field != "" { // repeated spaces
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		lines = append(lines, &batchLine{
+			num:    num,
+			action: strings.ToLower(fields[0]),
+			args:   fields[1:],
+		})
+	}
+
+	return lines, nil
+}
+
+// executeBatch executes one transaction for each line of a batch file. The
+// inputs of every line are built before any are broadcast, so a mistake in
+// the file does not leave it partially executed. If broadcasting fails, the
+// hashes of the transactions already broadcast are returned with the error.
+// If nonce is positive, the transactions use consecutive nonces starting with
+// it.
+func executeBatch(ctx context.Context, cl clientType.Client, dbid string, r io.Reader, nonce int64, sync bool) ([]transactions.TxHash, error) {
+	lines, err := readBatchLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading batch file: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no transactions in batch file")
+	}
+
+	schema, err := cl.GetSchema(ctx, dbid)
+	if err != nil {
+		return nil, fmt.Errorf("error getting schema: %w", err)
+	}
+
+	inputs := make([][][]any, len(lines))
+	for i, line := range lines {
+		parsedArgs, err := parseInputs(line.args)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: error parsing inputs: %w", line.num, err)
+		}
+		inputs[i], err = buildSchemaInputs(schema, line.action, parsedArgs)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: error getting inputs: %w", line.num, err)
+		}
+	}
+
+	txHashes := make([]transactions.TxHash, 0, len(lines))
+	for i, line := range lines {
+		opts := []clientType.TxOpt{clientType.WithSyncBroadcast(sync)}
+		if nonce > 0 {
+			opts = append(opts, clientType.WithNonce(nonce+int64(i)))
+		}
+		txHash, err := cl.Execute(ctx, dbid, line.action, inputs[i], opts...)
+		if err != nil {
+			return txHashes, fmt.Errorf("line %d: error executing action: %w", line.num, err)
+		}
+		txHashes = append(txHashes, txHash)
+	}
+
+	return txHashes, nil
+}
+
 // inputs will be received as args.  The args will be in the form of
 // $argname:value.  Example $username:satoshi $age:32
 func parseInputs(args []string) ([]map[string]string, error) {
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/cmd/common/display"
+	"github.com/kwilteam/kwil-db/core/types"
+	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchClient is a clientType.Client that only implements GetSchema and
+// Execute, recording the executed transactions.
+type batchClient struct {
+	clientType.Client
+	schema   *types.Schema
+	executed []string
+	tuples   [][][]any
+	nonces   []int64
+	failAt   int
+}
+
+func (c *batchClient) GetSchema(ctx context.Context, dbid string) (*types.Schema, error) {
+	return c.schema, nil
+}
+
+func (c *batchClient) Execute(ctx context.Context, dbid string, action string, tuples [][]any, opts ...clientType.TxOpt) (transactions.TxHash, error) {
+	if c.failAt > 0 && len(c.executed)+1 == c.failAt {
+		return nil, errors.New("broadcast failed")
+	}
+	c.executed = append(c.executed, action)
+	c.tuples = append(c.tuples, tuples)
+	c.nonces = append(c.nonces, clientType.GetTxOpts(opts).Nonce)
+	return transactions.TxHash{byte(len(c.executed))}, nil
+}
+
+var batchSchema = &types.Schema{
+	Name: "db",
+	Actions: []*types.Action{{
+		Name:       "create_user",
+		Parameters: []string{"$name", "$age"},
+	}},
+	Procedures: []*types.Procedure{{
+		Name:       "delete_user",
+		Parameters: []*types.ProcedureParameter{{Name: "$name", Type: types.TextType}},
+	}},
+}
+
+func Test_executeBatch(t *testing.T) {
+	ctx := context.Background()
+	const batch = `# users
+create_user name:satoshi age:32
+Create_User  "name:hal finney" age:54
+
+delete_user name:satoshi
+`
+
+	cl := &batchClient{schema: batchSchema}
+	txHashes, err := executeBatch(ctx, cl, "xdbid", strings.NewReader(batch), -1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []transactions.TxHash{{1}, {2}, {3}}, txHashes)
+	assert.Equal(t, []string{"create_user", "create_user", "delete_user"}, cl.executed)
+	assert.Equal(t, [][][]any{
+		{{"satoshi", "32"}},
+		{{"hal finney", "54"}},
+		{{"satoshi"}},
+	}, cl.tuples)
+	assert.Equal(t, []int64{0, 0, 0}, cl.nonces)
+
+	// Consecutive nonces from an override.
+	cl = &batchClient{schema: batchSchema}
+	_, err = executeBatch(ctx, cl, "xdbid", strings.NewReader(batch), 7, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 8, 9}, cl.nonces)
+
+	// Nothing is broadcast if any line is invalid.
+	cl = &batchClient{schema: batchSchema}
+	_, err = executeBatch(ctx, cl, "xdbid", strings.NewReader(batch+"drop_user name:x\n"), -1, false)
+	require.ErrorContains(t, err, "line 6")
+	assert.Empty(t, cl.executed)
+
+	_, err = executeBatch(ctx, cl, "xdbid", strings.NewReader("create_user satoshi\n"), -1, false)
+	require.ErrorContains(t, err, "line 1")
+	assert.Empty(t, cl.executed)
+
+	_, err = executeBatch(ctx, cl, "xdbid", strings.NewReader("# nothing\n\n"), -1, false)
+	require.Error(t, err)
+
+	// The hashes broadcast before a failure are returned.
+	cl = &batchClient{schema: batchSchema, failAt: 3}
+	txHashes, err = executeBatch(ctx, cl, "xdbid", strings.NewReader(batch), -1, false)
+	require.ErrorContains(t, err, "line 5")
+	assert.Equal(t, []transactions.TxHash{{1}, {2}}, txHashes)
+}
+
+func Example_respTxHashes_text() {
+	display.Print(respTxHashes{{0xab}, {0xcd}}, nil, "text")
+	// Output:
+	// TxHash: ab
+	// TxHash: cd
+}
+
+func Example_respTxHashes_json() {
+	display.Print(respTxHashes{{0xab}, {0xcd}}, nil, "json")
+	// Output:
+	// {
+	//   "result": [
+	//     {
+	//       "tx_hash": "ab"
+	//     },
+	//     {
+	//       "tx_hash": "cd"
+	//     }
+	//   ],
+	//   "error": ""
+	// }
+}
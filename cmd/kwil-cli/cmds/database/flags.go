@@ -5,8 +5,7 @@ import (
 	"fmt"
 
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
-	"github.com/kwilteam/kwil-db/core/crypto/auth"
-	"github.com/kwilteam/kwil-db/core/utils"
+	"github.com/kwilteam/kwil-db/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -39,12 +38,7 @@ func getSelectedOwner(cmd *cobra.Command, conf *config.KwilCliConfig) ([]byte, e
 		}
 
 	} else {
-		if conf.PrivateKey == nil {
-			return nil, nil // nil is a valid owner, as it will return all
-		}
-
-		signer := auth.EthPersonalSigner{Key: *conf.PrivateKey}
-		ident = signer.Identity()
+		ident = conf.Identity() // nil is a valid owner, as it will return all
 	}
 
 	return ident, nil
@@ -73,5 +67,5 @@ func getSelectedDbid(cmd *cobra.Command, conf *config.KwilCliConfig) (string, er
 		return "", fmt.Errorf("failed to get owner flag: %w", err)
 	}
 
-	return utils.GenerateDBID(name, owner), nil
+	return types.GenerateDBID(name, owner), nil
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/common"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
-	"github.com/kwilteam/kwil-db/core/crypto/auth"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
 	"github.com/spf13/cobra"
 )
@@ -49,10 +48,10 @@ func listCmd() *cobra.Command {
 
 				var ownerIdent []byte
 				if self {
-					if conf.PrivateKey == nil {
+					ownerIdent = conf.Identity()
+					if ownerIdent == nil {
 						return display.PrintErr(cmd, errors.New("must have a configured wallet to use --self"))
 					}
-					ownerIdent = (&auth.EthPersonalSigner{Key: *conf.PrivateKey}).Identity()
 				} else if owner != "" {
 					var err error
 					ownerIdent, err = hex.DecodeString(owner)
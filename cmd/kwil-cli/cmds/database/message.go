@@ -2,12 +2,20 @@ package database
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
+	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+	"github.com/kwilteam/kwil-db/parse"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -51,11 +59,153 @@ func (d *respDBList) MarshalText() ([]byte, error) {
 	return msg.Bytes(), nil
 }
 
+// respTxHashes is the hashes of the transactions broadcast by a batch.
+type respTxHashes []transactions.TxHash
+
+func (h respTxHashes) MarshalJSON() ([]byte, error) {
+	hashes := make([]display.RespTxHash, len(h))
+	for i, hash := range h {
+		hashes[i] = display.RespTxHash(hash)
+	}
+	return json.Marshal(hashes)
+}
+
+func (h respTxHashes) MarshalText() ([]byte, error) {
+	var msg bytes.Buffer
+	for i, hash := range h {
+		msg.WriteString(fmt.Sprintf("TxHash: %x", hash))
+		if i != len(h)-1 {
+			msg.WriteString("\n")
+		}
+	}
+	return msg.Bytes(), nil
+}
+
 // respRelations is a slice of maps that represent the relations(from set theory)
 // of a database in cli
 type respRelations struct {
 	// to avoid recursive call of MarshalJSON
 	Data *clientType.Records
+	// Columns are the names of the result columns in order, if known, such as
+	// from the return types of a procedure.
+	Columns []string
+	// Types are the known types of result columns by name. Since results are
+	// JSON, these are needed to format values such as blobs, which are base64
+	// strings in the result.
+	Types map[string]*types.DataType
+}
+
+// procedureColumns returns the result columns and their types for a procedure
+// in the schema. Actions do not declare their results, so nothing is returned
+// for them.
+func procedureColumns(schema *types.Schema, name string) ([]string, map[string]*types.DataType) {
+	for _, p := range schema.Procedures {
+		if !strings.EqualFold(p.Name, name) || p.Returns == nil {
+			continue
+		}
+		cols := make([]string, len(p.Returns.Fields))
+		colTypes := make(map[string]*types.DataType, len(p.Returns.Fields))
+		for i, field := range p.Returns.Fields {
+			cols[i] = field.Name
+			colTypes[field.Name] = field.Type
+		}
+		return cols, colTypes
+	}
+	return nil, nil
+}
+
+// queryColumnTypes returns the types of the result columns of an ad hoc query
+// by name, for formatting its results. Only columns that are taken directly
+// from exactly one table column are included. Columns that are computed or
+// cast are left out, as are all columns of a query that cannot be analyzed,
+// such as a compound SELECT or one that selects from a subquery.
+func queryColumnTypes(schema *types.Schema, query string) map[string]*types.DataType {
+	res, err := parse.ParseSQL(query, schema)
+	if err != nil || res.ParseErrs.Err() != nil {
+		return nil
+	}
+	sel, ok := res.AST.SQL.(*parse.SelectStatement)
+	if !ok || len(sel.SelectCores) != 1 || len(res.AST.CTEs) > 0 {
+		return nil
+	}
+	core := sel.SelectCores[0]
+
+	// the tables in the FROM clause and joins, by alias or name
+	var relations []parse.Table
+	if core.From != nil {
+		relations = append(relations, core.From)
+	}
+	for _, j := range core.Joins {
+		relations = append(relations, j.Relation)
+	}
+	tables := make(map[string]*types.Table)
+	var tableOrder []string
+	for _, rel := range relations {
+		rt, ok := rel.(*parse.RelationTable)
+		if !ok {
+			return nil
+		}
+		tbl, ok := schema.FindTable(rt.Table)
+		if !ok {
+			return nil
+		}
+		name := strings.ToLower(rt.Table)
+		if rt.Alias != "" {
+			name = strings.ToLower(rt.Alias)
+		}
+		tables[name] = tbl
+		tableOrder = append(tableOrder, name)
+	}
+
+	// findColumn finds the table column a column reference is to. An
+	// unqualified reference must match a column in exactly one table.
+	findColumn := func(table, column string) *types.Column {
+		var found *types.Column
+		for _, name := range tableOrder {
+			if table != "" && name != strings.ToLower(table) {
+				continue
+			}
+			if col, ok := tables[name].FindColumn(column); ok {
+				if found != nil {
+					return nil
+				}
+				found = col
+			}
+		}
+		return found
+	}
+
+	// The analyzer rejects duplicate result column names, so each name is for
+	// one result column.
+	colTypes := make(map[string]*types.DataType)
+	for _, rc := range core.Columns {
+		switch rc := rc.(type) {
+		case *parse.ResultColumnWildcard:
+			for _, name := range tableOrder {
+				if rc.Table != "" && name != strings.ToLower(rc.Table) {
+					continue
+				}
+				for _, col := range tables[name].Columns {
+					colTypes[col.Name] = col.Type
+				}
+			}
+		case *parse.ResultColumnExpression:
+			colExpr, ok := rc.Expression.(*parse.ExpressionColumn)
+			if !ok || colExpr.TypeCast != nil {
+				continue
+			}
+			name := colExpr.Column
+			if rc.Alias != "" {
+				name = rc.Alias
+			}
+			if col := findColumn(colExpr.Table, colExpr.Column); col != nil {
+				colTypes[name] = col.Type
+			}
+		default:
+			return nil
+		}
+	}
+	return colTypes
 }
 
 func (r *respRelations) MarshalJSON() ([]byte, error) {
@@ -97,6 +247,98 @@ func (r *respRelations) MarshalText() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// MarshalCSV writes the records with a header row. The columns are in the
+// order of Columns if it is set, and otherwise in sorted order as with
+// MarshalText, since the records do not preserve the order of the query. The
+// header is written even with no records if the columns are known. Blobs are
+// hex encoded, and other values, including decimals, are written in their
+// string form.
+func (r *respRelations) MarshalCSV() ([]byte, error) {
+	data := r.Data.Export()
+
+	headers := slices.Clone(r.Columns)
+	var extra []string
+	if len(data) > 0 {
+		for k := range data[0] {
+			if !slices.Contains(headers, k) {
+				extra = append(extra, k)
+			}
+		}
+	}
+	sort.Strings(extra)
+	headers = append(headers, extra...)
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+
+	for _, row := range data {
+		rs := make([]string, len(headers))
+		for i, h := range headers {
+			rs[i] = csvValue(row[h], r.Types[h])
+		}
+		if err := w.Write(rs); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvValue formats a value from a query result for a CSV field. The type, if
+// not nil, is the column's type. A blob column's values are base64 strings in
+// a JSON result, which are written as hex.
+func csvValue(v any, dt *types.DataType) string {
+	if dt != nil && dt.Name == types.BlobType.Name {
+		if dt.IsArray {
+			if arr, ok := v.([]any); ok {
+				elems := make([]any, len(arr))
+				for i, elem := range arr {
+					elems[i] = csvBlob(elem)
+				}
+				return fmt.Sprintf("%v", elems)
+			}
+		} else {
+			return csvBlob(v)
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return hex.EncodeToString(v)
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// csvBlob hex encodes a blob value, which may be a base64 string. Values that
+// are not valid base64 are written as is.
+func csvBlob(v any) string {
+	if s, ok := v.(string); ok {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return s
+		}
+		v = b
+	}
+	return csvValue(v, nil)
+}
+
 // respSchema is used to represent a database schema in cli
 type respSchema struct {
 	Schema *types.Schema
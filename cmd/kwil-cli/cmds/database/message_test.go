@@ -2,10 +2,14 @@ package database
 
 import (
 	"encoding/hex"
+	"testing"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	jsonUtil "github.com/kwilteam/kwil-db/core/utils/json"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Example_respDBlist_text_0() {
@@ -124,6 +128,58 @@ func Example_respRelations_json() {
 	// }
 }
 
+// mustRecordsFromJSON decodes a JSON result as the client does, so values are
+// as they are in real results, e.g. blobs are base64 strings.
+func mustRecordsFromJSON(result string) *clientType.Records {
+	maps, err := jsonUtil.UnmarshalMapWithoutFloat([]byte(result))
+	if err != nil {
+		panic(err)
+	}
+	return clientType.NewRecordsFromMaps(maps)
+}
+
+func Example_respRelations_csv() {
+	display.Print(&respRelations{
+		Data: mustRecordsFromJSON(`[
+			{"id": 1, "name": "alice, bob", "data": "3q0=", "price": "12.50", "tags": ["AQ==", "Ag=="]},
+			{"id": 2, "name": "say \"hi\"", "data": null, "price": "0.01", "tags": []}
+		]`),
+		Types: map[string]*types.DataType{
+			"data": types.BlobType,
+			"tags": types.ArrayType(types.BlobType),
+		}},
+		nil, "csv")
+	// Output:
+	// data,id,name,price,tags
+	// dead,1,"alice, bob",12.50,[01 02]
+	// ,2,"say ""hi""",0.01,[]
+}
+
+func Example_respRelations_csv_columns() {
+	display.Print(&respRelations{
+		Data:    mustRecordsFromJSON(`[{"name": "alice", "id": 1}]`),
+		Columns: []string{"name", "id"},
+		Types:   map[string]*types.DataType{"name": types.TextType, "id": types.IntType},
+	}, nil, "csv")
+	// Output:
+	// name,id
+	// alice,1
+}
+
+func Example_respRelations_csv_empty() {
+	display.Print(&respRelations{
+		Data:    mustRecordsFromJSON(`[]`),
+		Columns: []string{"name", "id"},
+	}, nil, "csv")
+	// Output:
+	// name,id
+}
+
+func Example_respRelations_csv_empty_unknown() {
+	display.Print(&respRelations{Data: clientType.NewRecordsFromMaps(nil)}, nil, "csv")
+	// Output:
+}
+
 var demoSchema = &respSchema{
 	Schema: &types.Schema{
 		Owner: []byte("user"),
@@ -203,3 +259,90 @@ func Example_respSchema_text() {
 	//     Inputs: [user_id]
 	// Procedures:
 }
+
+func Test_queryColumnTypes(t *testing.T) {
+	schema := &types.Schema{
+		Name: "test",
+		Tables: []*types.Table{
+			{
+				Name: "users",
+				Columns: []*types.Column{
+					{Name: "id", Type: types.IntType, Attributes: []*types.Attribute{{Type: types.PRIMARY_KEY}}},
+					{Name: "name", Type: types.TextType},
+					{Name: "data", Type: types.BlobType},
+				},
+			},
+			{
+				Name: "posts",
+				Columns: []*types.Column{
+					{Name: "id", Type: types.IntType, Attributes: []*types.Attribute{{Type: types.PRIMARY_KEY}}},
+					{Name: "author_id", Type: types.IntType},
+					{Name: "data", Type: types.TextType},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  map[string]*types.DataType
+	}{
+		{
+			name:  "wildcard",
+			query: "SELECT * FROM users",
+			want:  map[string]*types.DataType{"id": types.IntType, "name": types.TextType, "data": types.BlobType},
+		},
+		{
+			name:  "columns",
+			query: "SELECT name, data FROM users",
+			want:  map[string]*types.DataType{"name": types.TextType, "data": types.BlobType},
+		},
+		{
+			name:  "aliased column",
+			query: "SELECT data AS payload FROM users",
+			want:  map[string]*types.DataType{"payload": types.BlobType},
+		},
+		{
+			name:  "renamed to another table's column",
+			query: "SELECT name AS data FROM users",
+			want:  map[string]*types.DataType{"data": types.TextType},
+		},
+		{
+			name:  "qualified columns in a join",
+			query: "SELECT u.data, p.id FROM users AS u INNER JOIN posts AS p ON u.id = p.author_id",
+			want:  map[string]*types.DataType{"data": types.BlobType, "id": types.IntType},
+		},
+		{
+			name:  "unqualified columns in a join",
+			query: "SELECT name, author_id FROM users AS u INNER JOIN posts AS p ON u.id = p.author_id",
+			want:  map[string]*types.DataType{"name": types.TextType, "author_id": types.IntType},
+		},
+		{
+			name:  "computed and cast",
+			query: "SELECT length(name) AS data, id::text FROM users",
+			want:  map[string]*types.DataType{},
+		},
+		{
+			name:  "compound",
+			query: "SELECT data FROM users UNION SELECT data FROM posts",
+			want:  nil,
+		},
+		{
+			name:  "subquery",
+			query: "SELECT data FROM (SELECT data FROM users) AS s",
+			want:  nil,
+		},
+		{
+			name:  "invalid",
+			query: "SELECT nope FROM users",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, queryColumnTypes(schema, tt.query))
+		})
+	}
+}
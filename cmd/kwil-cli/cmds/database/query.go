@@ -21,7 +21,10 @@ flags, or you can specify the database by passing the database id with the ` + "
 flag is passed and no ` + "`" + `--owner` + "`" + ` flag is passed, the owner will be inferred from your configured wallet.`
 
 	queryExample = `# Querying the "users" table in the "mydb" database
-kwil-cli database query "SELECT * FROM users WHERE age > 25" --name mydb --owner 0x9228624C3185FCBcf24c1c9dB76D8Bef5f5DAd64`
+kwil-cli database query "SELECT * FROM users WHERE age > 25" --name mydb --owner 0x9228624C3185FCBcf24c1c9dB76D8Bef5f5DAd64
+
+# Writing the results as CSV
+kwil-cli database query "SELECT * FROM users" --name mydb --output csv > users.csv`
 )
 
 func queryCmd() *cobra.Command {
@@ -44,7 +47,18 @@ func queryCmd() *cobra.Command {
 						return display.PrintErr(cmd, fmt.Errorf("error querying database: %w", err))
 					}
 
-					return display.PrintCmd(cmd, &respRelations{Data: data})
+					rel := &respRelations{Data: data}
+					if format, _ := cmd.Flags().GetString("output"); format == "csv" {
+						// Blobs are base64 strings in the result, so the CSV
+						// output needs the column types to write them as hex.
+						schema, err := client.GetSchema(ctx, dbid)
+						if err != nil {
+							return display.PrintErr(cmd, fmt.Errorf("error getting schema: %w", err))
+						}
+						rel.Types = queryColumnTypes(schema, args[0])
+					}
+
+					return display.PrintCmd(cmd, rel)
 				})
 		},
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/configure"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/database"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/utils"
+	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/validators"
 	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
 )
 
@@ -27,6 +28,7 @@ func NewRootCmd() *cobra.Command {
 		configure.NewCmdConfigure(),
 		database.NewCmdDatabase(),
 		utils.NewCmdUtils(),
+		validators.NewCmdValidators(),
 		version.NewVersionCmd(),
 	)
 
@@ -3,7 +3,9 @@ package utils
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
@@ -19,11 +21,25 @@ func decodeTx(txBts []byte) (*transaction, error) {
 		return nil, err
 	}
 	return &transaction{
-		Raw: txBts,
-		Tx:  &tx,
+		Raw:    txBts,
+		Tx:     &tx,
+		SigErr: tx.Verify(),
 	}, nil
 }
 
+// decodeTxString decodes a raw transaction in either hex or base64.
+func decodeTxString(txStr string) ([]byte, error) {
+	txBts, err := hex.DecodeString(txStr)
+	if err == nil {
+		return txBts, nil
+	}
+	txBts, err64 := base64.StdEncoding.DecodeString(txStr)
+	if err64 != nil {
+		return nil, fmt.Errorf("transaction is neither hex nor base64: %w", err)
+	}
+	return txBts, nil
+}
+
 // fromStdIn returns a line from the input reader and trims leading and trailing
 // whitespace.
 func fromStdIn(in io.Reader) ([]byte, error) {
@@ -37,10 +53,13 @@ func fromStdIn(in io.Reader) ([]byte, error) {
 func decodeTxCmd() *cobra.Command {
 	var withPayload bool
 	var cmd = &cobra.Command{
-		Use:   "decode-tx",
+		Use:   "decode-tx <raw-tx>",
 		Short: "Decodes a raw transaction.",
-		Long:  "Decodes a raw transaction. Given the bytes of a transaction in base64, give a structured output.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Decodes a raw transaction. Given the bytes of a transaction in hex or base64, give a structured output.
+The signature is verified, although only the signature types built into kwil-cli are recognized.
+High-S secp256k1 signatures are reported as invalid, although nodes accept them until the "strict_sigs" hardfork.
+Use "-" to read the transaction from stdin.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var txStr string
 			var err error
@@ -54,7 +73,7 @@ func decodeTxCmd() *cobra.Command {
 			} else {
 				txStr = args[0]
 			}
-			txBts, err := hex.DecodeString(txStr)
+			txBts, err := decodeTxString(txStr)
 			if err != nil {
 				return display.PrintErr(cmd, err)
 			}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeActionTx is an execute_action transaction calling create_user("alice")
+// on xdbid, signed with an ed25519 key.
+const (
+	executeActionTx     = "0001f8baf84ab84013d6f996305922d9d80073e07e9595fe0ade995164cde0fe5fb6e6ae9aeab51dec174f8774b75a414102af4ce63541aa5a4f36036329c35e6d23a20dfd6c93048765643235353139f84480a60001e38578646269648b6372656174655f75736572d0cfcec6847465787480c685616c69636587657865637574658203e8078f6b77696c2d746573742d636861696e86636f6e636174a089f12c96b976f08c8daebbc2b034f7d0ed9dd52e7d7d2baaef22957075308033"
+	executeActionSender = "89f12c96b976f08c8daebbc2b034f7d0ed9dd52e7d7d2baaef22957075308033"
+)
+
+func Test_decodeTx(t *testing.T) {
+	txBts, err := decodeTxString(executeActionTx)
+	require.NoError(t, err)
+
+	tx, err := decodeTx(txBts)
+	require.NoError(t, err)
+	require.NoError(t, tx.SigErr)
+
+	assert.Equal(t, executeActionSender, hex.EncodeToString(tx.Tx.Sender))
+	assert.Equal(t, uint64(7), tx.Tx.Body.Nonce)
+	assert.Equal(t, "1000", tx.Tx.Body.Fee.String())
+	assert.Equal(t, transactions.PayloadTypeExecute, tx.Tx.Body.PayloadType)
+
+	tx.WithPayload = true
+	jsonBts, err := tx.MarshalJSON()
+	require.NoError(t, err)
+	var out struct {
+		SignatureValid bool `json:"signature_valid"`
+		Payload        struct {
+			DBID   string
+			Action string
+		} `json:"payload_decoded"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBts, &out))
+	assert.True(t, out.SignatureValid)
+	assert.Equal(t, "xdbid", out.Payload.DBID)
+	assert.Equal(t, "create_user", out.Payload.Action)
+
+	text, err := tx.MarshalText()
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "Signature valid: yes\n")
+	assert.Contains(t, string(text), "Payload (json): ")
+
+	// base64 is also accepted
+	txBts64, err := decodeTxString(base64.StdEncoding.EncodeToString(txBts))
+	require.NoError(t, err)
+	assert.Equal(t, txBts, txBts64)
+
+	_, err = decodeTxString("not a transaction!")
+	require.Error(t, err)
+}
+
+func Test_decodeTx_invalid(t *testing.T) {
+	txBts, err := hex.DecodeString(executeActionTx)
+	require.NoError(t, err)
+	txBts[8] ^= 0xff // first byte of the signature
+
+	tx, err := decodeTx(txBts)
+	require.NoError(t, err)
+	require.Error(t, tx.SigErr)
+
+	text, err := tx.MarshalText()
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "Signature valid: no (")
+
+	// An unknown payload type is shown as base64 with the error.
+	tx.Tx.Body.PayloadType = "not_a_payload"
+	tx.WithPayload = true
+	text, err = tx.MarshalText()
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "Payload (unknown payload type): ")
+
+	jsonBts, err := tx.MarshalJSON()
+	require.NoError(t, err)
+	var out struct {
+		SignatureValid bool   `json:"signature_valid"`
+		PayloadError   string `json:"payload_error"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBts, &out))
+	assert.False(t, out.SignatureValid)
+	assert.Equal(t, "unknown payload type", out.PayloadError)
+}
+
+func Test_decodeTx_malformedPayload(t *testing.T) {
+	txBts, err := hex.DecodeString(executeActionTx)
+	require.NoError(t, err)
+
+	tx, err := decodeTx(txBts)
+	require.NoError(t, err)
+
+	// A committed transaction may have a payload that fails validation, which
+	// is still shown, along with the error.
+	payload, err := (&transactions.ActionExecution{DBID: "xdbid"}).MarshalBinary() // no action
+	require.NoError(t, err)
+	tx.Tx.Body.Payload = payload
+	tx.WithPayload = true
+
+	jsonBts, err := tx.MarshalJSON()
+	require.NoError(t, err)
+	var out struct {
+		Payload struct {
+			DBID string
+		} `json:"payload_decoded"`
+		PayloadError string `json:"payload_error"`
+	}
+	require.NoError(t, json.Unmarshal(jsonBts, &out))
+	assert.Equal(t, "xdbid", out.Payload.DBID)
+	assert.Contains(t, out.PayloadError, transactions.ErrMalformedPayload.Error())
+
+	text, err := tx.MarshalText()
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "Payload (json): ")
+	assert.Contains(t, string(text), "Payload error: ")
+}
@@ -18,45 +18,66 @@ type transaction struct {
 	Raw         []byte
 	Tx          *transactions.Transaction
 	WithPayload bool
+	SigErr      error // from Tx.Verify, nil if the signature is valid
 }
 
 // remarshalPayload attempt to decode and remarshal the payload from RLP to JSON.
-func (t *transaction) remarshalPayload() (json.RawMessage, bool) {
-	payloadObject, err := transactions.UnmarshalPayload(t.Tx.Body.PayloadType, t.Tx.Body.Payload)
+// Committed transactions need not have well-formed payloads, so the payload is
+// shown even if it fails validation, which is reported in invalidErr.
+func (t *transaction) remarshalPayload() (payloadJSON json.RawMessage, invalidErr, err error) {
+	payloadObject, err := transactions.DecodePayload(t.Tx.Body.PayloadType, t.Tx.Body.Payload)
 	if err != nil {
-		return nil, false
+		return nil, nil, err
 	}
-	payloadJSON, err := json.Marshal(payloadObject)
+	payloadJSON, err = json.Marshal(payloadObject)
 	if err != nil {
-		return nil, false
+		return nil, nil, err
 	}
-	return payloadJSON, true
+	return payloadJSON, transactions.ValidatePayload(payloadObject), nil
 }
 
 func (t *transaction) MarshalJSON() ([]byte, error) {
+	tx := struct {
+		Tx             *transactions.Transaction `json:"tx"`
+		SignatureValid bool                      `json:"signature_valid"`
+		SignatureError string                    `json:"signature_error,omitempty"`
+		Payload        json.RawMessage           `json:"payload_decoded,omitempty"`
+		PayloadError   string                    `json:"payload_error,omitempty"`
+	}{
+		Tx:             t.Tx,
+		SignatureValid: t.SigErr == nil,
+	}
+	if t.SigErr != nil {
+		tx.SignatureError = t.SigErr.Error()
+	}
+
 	if t.WithPayload {
-		payloadJSON, _ := t.remarshalPayload()
-		tx := struct {
-			Tx      *transactions.Transaction `json:"tx"`
-			Payload json.RawMessage           `json:"payload_decoded"`
-		}{
-			Tx:      t.Tx,
-			Payload: payloadJSON,
+		payloadJSON, invalidErr, err := t.remarshalPayload()
+		if err != nil {
+			tx.PayloadError = err.Error()
+		} else if invalidErr != nil {
+			tx.PayloadError = invalidErr.Error()
+		}
+		tx.Payload = payloadJSON
+	} else {
+		// Decode a fresh Transaction instance and zero out the Payload.
+		var bareTx transactions.Transaction
+		if err := bareTx.UnmarshalBinary(t.Raw); err != nil {
+			return nil, err
 		}
-		return json.MarshalIndent(tx, "", "  ")
+		bareTx.Body.Payload = nil
+		tx.Tx = &bareTx
 	}
 
-	// Decode a fresh Transaction instance and zero out the Payload.
-	tx, err := decodeTx(t.Raw)
-	if err != nil {
-		return nil, err
-	}
-	tx.Tx.Body.Payload = nil
 	return json.MarshalIndent(tx, "", "  ")
 }
 
 func (t *transaction) MarshalText() ([]byte, error) {
 	txHash := sha256.Sum256(t.Raw) // tmhash is sha256
+	sigValid := "yes"
+	if t.SigErr != nil {
+		sigValid = "no (" + t.SigErr.Error() + ")"
+	}
 	msg := fmt.Sprintf(`Transaction ID: %x
 Sender: %s
 Description: %s
@@ -66,6 +87,7 @@ Fee: %s
 Nonce: %d
 Signature type: %s
 Signature: %s
+Signature valid: %s
 `,
 		txHash,
 		hex.EncodeToString(t.Tx.Sender), // hex because it's an address or pubkey, probably address
@@ -76,16 +98,20 @@ Signature: %s
 		t.Tx.Body.Nonce,
 		t.Tx.Signature.Type,
 		base64.StdEncoding.EncodeToString(t.Tx.Signature.Signature),
+		sigValid,
 	)
 
 	if t.WithPayload { // put it at the end regardless since it' can be big
 		// First try to decode the transaction (RLP), then create readable JSON
 		// for its display. If either fails, show it as base64.
-		payloadJSON, ok := t.remarshalPayload()
-		if !ok {
-			msg += "Payload (invalid): " + base64.StdEncoding.EncodeToString(t.Tx.Body.Payload) + "\n"
+		payloadJSON, invalidErr, err := t.remarshalPayload()
+		if err != nil {
+			msg += fmt.Sprintf("Payload (%v): %s\n", err, base64.StdEncoding.EncodeToString(t.Tx.Body.Payload))
 		} else {
 			msg += "Payload (json): " + string(payloadJSON) + "\n"
+			if invalidErr != nil {
+				msg += "Payload error: " + invalidErr.Error() + "\n"
+			}
 		}
 	}
 
@@ -54,6 +54,7 @@ func Example_respKwilCliConfig_text() {
 	}, nil, "text")
 	// Output:
 	// PrivateKey: ***
+	// KeyType: secp256k1
 	// Provider: localhost:9090
 	// ChainID: chainid123
 }
@@ -75,6 +76,7 @@ func Example_respKwilCliConfig_json() {
 	// {
 	//   "result": {
 	//     "private_key": "***",
+	//     "key_type": "secp256k1",
 	//     "provider": "localhost:9090",
 	//     "chain_id": "chainid123"
 	//   },
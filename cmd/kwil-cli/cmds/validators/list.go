@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"context"
+
+	"github.com/kwilteam/kwil-db/cmd/common/display"
+	"github.com/kwilteam/kwil-db/cmd/kwil-cli/cmds/common"
+	"github.com/kwilteam/kwil-db/cmd/kwil-cli/config"
+	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listLong = `List the current validator set of the network, with each validator's public key, key type, and voting power.
+The total voting power is shown after the validators. Use ` + "`--output json`" + ` for machine-readable output.`
+
+	listExample = `# List the current validator set of the network
+kwil-cli validators list
+
+# List the validators as JSON
+kwil-cli validators list --output json`
+)
+
+func listCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the current validator set of the network.",
+		Long:    listLong,
+		Example: listExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return common.DialClient(cmd.Context(), cmd, common.WithoutPrivateKey, func(ctx context.Context, cl clientType.Client, _ *config.KwilCliConfig) error {
+				return listValidators(ctx, cmd, cl)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func listValidators(ctx context.Context, cmd *cobra.Command, cl clientType.Client) error {
+	vals, err := cl.Validators(ctx)
+	if err != nil {
+		return display.PrintErr(cmd, err)
+	}
+
+	return display.PrintCmd(cmd, &respValidators{Validators: vals})
+}
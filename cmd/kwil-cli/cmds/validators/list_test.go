@@ -0,0 +1,66 @@
+package validators
+
+import (
+	"context"
+
+	"github.com/kwilteam/kwil-db/core/types"
+	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/spf13/cobra"
+)
+
+// validatorsClient is a clientType.Client that only implements Validators.
+type validatorsClient struct {
+	clientType.Client
+}
+
+func (validatorsClient) Validators(ctx context.Context) ([]*types.Validator, error) {
+	return []*types.Validator{
+		{PubKey: []byte{0x01, 0x02}, Power: 1},
+		{PubKey: []byte{0x03, 0x04}, Power: 2},
+		{PubKey: []byte{0x05, 0x06}, Power: 3},
+	}, nil
+}
+
+func newTestCmd(output string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", output, "")
+	return cmd
+}
+
+func Example_listValidators_text() {
+	listValidators(context.Background(), newTestCmd("text"), validatorsClient{})
+	// Output:
+	// Current validator set:
+	//   0. 0102 (ed25519), power 1
+	//   1. 0304 (ed25519), power 2
+	//   2. 0506 (ed25519), power 3
+	// Total power: 6
+}
+
+func Example_listValidators_json() {
+	listValidators(context.Background(), newTestCmd("json"), validatorsClient{})
+	// Output:
+	// {
+	//   "result": {
+	//     "validators": [
+	//       {
+	//         "pubkey": "0102",
+	//         "key_type": "ed25519",
+	//         "power": 1
+	//       },
+	//       {
+	//         "pubkey": "0304",
+	//         "key_type": "ed25519",
+	//         "power": 2
+	//       },
+	//       {
+	//         "pubkey": "0506",
+	//         "key_type": "ed25519",
+	//         "power": 3
+	//       }
+	//     ],
+	//     "total_power": 6
+	//   },
+	//   "error": ""
+	// }
+}
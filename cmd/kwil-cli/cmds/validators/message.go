@@ -0,0 +1,63 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kwilteam/kwil-db/core/types"
+)
+
+// validatorKeyType is the key type of all validators, which are CometBFT
+// validators with ed25519 keys.
+const validatorKeyType = "ed25519"
+
+// respValidators represents the current validator set in cli
+type respValidators struct {
+	Validators []*types.Validator
+}
+
+func (r *respValidators) totalPower() int64 {
+	var total int64
+	for _, v := range r.Validators {
+		total += v.Power
+	}
+	return total
+}
+
+type validatorInfo struct {
+	PubKey  string `json:"pubkey"`
+	KeyType string `json:"key_type"`
+	Power   int64  `json:"power"`
+}
+
+func (r *respValidators) MarshalJSON() ([]byte, error) {
+	vals := make([]validatorInfo, len(r.Validators))
+	for i, v := range r.Validators {
+		vals[i] = validatorInfo{
+			PubKey:  hex.EncodeToString(v.PubKey),
+			KeyType: validatorKeyType,
+			Power:   v.Power,
+		}
+	}
+
+	return json.Marshal(struct {
+		Validators []validatorInfo `json:"validators"`
+		TotalPower int64           `json:"total_power"`
+	}{
+		Validators: vals,
+		TotalPower: r.totalPower(),
+	})
+}
+
+func (r *respValidators) MarshalText() ([]byte, error) {
+	var msg bytes.Buffer
+	msg.WriteString("Current validator set:\n")
+	for i, v := range r.Validators {
+		fmt.Fprintf(&msg, "% 3d. %x (%s), power %d\n", i, v.PubKey, validatorKeyType, v.Power)
+	}
+	fmt.Fprintf(&msg, "Total power: %d\n", r.totalPower())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,19 @@
+package validators
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmdValidators() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "validators",
+		Short: "Validator related commands.",
+		Long:  "Commands for getting information about the network's validators.",
+	}
+
+	cmd.AddCommand(
+		listCmd(),
+	)
+
+	return cmd
+}
@@ -14,29 +14,59 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Supported values of the key_type setting.
+const (
+	KeyTypeSecp256k1 = "secp256k1"
+	KeyTypeEd25519   = "ed25519"
+)
+
 type KwilCliConfig struct {
 	PrivateKey *crypto.Secp256k1PrivateKey
-	Provider   string
-	ChainID    string
+	// Ed25519PrivateKey is set instead of PrivateKey if the configured key
+	// type is ed25519.
+	Ed25519PrivateKey *crypto.Ed25519PrivateKey
+	Provider          string
+	ChainID           string
 }
 
-// Identity returns the account ID, or nil if no private key is set. These are
-// the bytes of the ethereum address.
+// Signer returns the signer for the configured private key, or nil if no
+// private key is set. Secp256k1 keys sign with the ethereum personal_sign
+// scheme.
+func (c *KwilCliConfig) Signer() auth.Signer {
+	switch {
+	case c.PrivateKey != nil:
+		return &auth.EthPersonalSigner{Key: *c.PrivateKey}
+	case c.Ed25519PrivateKey != nil:
+		return &auth.Ed25519Signer{Ed25519PrivateKey: *c.Ed25519PrivateKey}
+	default:
+		return nil
+	}
+}
+
+// Identity returns the account ID, or nil if no private key is set. For
+// secp256k1 keys, these are the bytes of the ethereum address, and for ed25519
+// keys, the public key.
 func (c *KwilCliConfig) Identity() []byte {
-	if c.PrivateKey == nil {
+	signer := c.Signer()
+	if signer == nil {
 		return nil
 	}
-	signer := &auth.EthPersonalSigner{Key: *c.PrivateKey}
 	return signer.Identity()
 }
 
 func (c *KwilCliConfig) ToPersistedConfig() *kwilCliPersistedConfig {
-	var privKeyHex string
-	if c.PrivateKey != nil {
+	var privKeyHex, keyType string
+	switch {
+	case c.PrivateKey != nil:
 		privKeyHex = c.PrivateKey.Hex()
+		keyType = KeyTypeSecp256k1
+	case c.Ed25519PrivateKey != nil:
+		privKeyHex = c.Ed25519PrivateKey.Hex()
+		keyType = KeyTypeEd25519
 	}
 	return &kwilCliPersistedConfig{
 		PrivateKey: privKeyHex,
+		KeyType:    keyType,
 		Provider:   c.Provider,
 		ChainID:    c.ChainID,
 	}
@@ -53,6 +83,7 @@ func DefaultKwilCliPersistedConfig() *kwilCliPersistedConfig {
 type kwilCliPersistedConfig struct {
 	// NOTE: `mapstructure` is used by viper, name is same as the viper key name
 	PrivateKey string `mapstructure:"private_key" json:"private_key,omitempty"`
+	KeyType    string `mapstructure:"key_type" json:"key_type,omitempty"`
 	Provider   string `mapstructure:"provider" json:"provider,omitempty"`
 	ChainID    string `mapstructure:"chain_id" json:"chain_id,omitempty"`
 }
@@ -69,11 +100,22 @@ func (c *kwilCliPersistedConfig) toKwilCliConfig() (*KwilCliConfig, error) {
 	}
 
 	// we should complain if the private key is configured and invalid
-	privateKey, err := crypto.Secp256k1PrivateKeyFromHex(c.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	switch c.KeyType {
+	case "", KeyTypeSecp256k1:
+		privateKey, err := crypto.Secp256k1PrivateKeyFromHex(c.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		kwilConfig.PrivateKey = privateKey
+	case KeyTypeEd25519:
+		privateKey, err := crypto.Ed25519PrivateKeyFromHex(c.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ed25519 private key: %w", err)
+		}
+		kwilConfig.Ed25519PrivateKey = privateKey
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", c.KeyType)
 	}
-	kwilConfig.PrivateKey = privateKey
 
 	return kwilConfig, nil
 }
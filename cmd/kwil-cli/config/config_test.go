@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toKwilCliConfig_KeyType(t *testing.T) {
+	secpKey, err := crypto.GenerateSecp256k1Key()
+	require.NoError(t, err)
+	edKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		conf    *kwilCliPersistedConfig
+		signer  auth.Signer
+		wantErr bool
+	}{
+		{
+			name:   "default secp256k1",
+			conf:   &kwilCliPersistedConfig{PrivateKey: secpKey.Hex()},
+			signer: &auth.EthPersonalSigner{Key: *secpKey},
+		},
+		{
+			name:   "secp256k1",
+			conf:   &kwilCliPersistedConfig{PrivateKey: secpKey.Hex(), KeyType: KeyTypeSecp256k1},
+			signer: &auth.EthPersonalSigner{Key: *secpKey},
+		},
+		{
+			name:   "ed25519",
+			conf:   &kwilCliPersistedConfig{PrivateKey: edKey.Hex(), KeyType: KeyTypeEd25519},
+			signer: &auth.Ed25519Signer{Ed25519PrivateKey: *edKey},
+		},
+		{
+			name:    "ed25519 key as secp256k1",
+			conf:    &kwilCliPersistedConfig{PrivateKey: edKey.Hex()},
+			wantErr: true,
+		},
+		{
+			name:    "unknown key type",
+			conf:    &kwilCliPersistedConfig{PrivateKey: secpKey.Hex(), KeyType: "rsa"},
+			wantErr: true,
+		},
+		{
+			name: "no key",
+			conf: &kwilCliPersistedConfig{KeyType: KeyTypeEd25519},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := tt.conf.toKwilCliConfig()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.signer == nil {
+				assert.Nil(t, conf.Signer())
+				assert.Nil(t, conf.Identity())
+				return
+			}
+			require.NotNil(t, conf.Signer())
+			assert.Equal(t, tt.signer.Identity(), conf.Identity())
+			assert.Equal(t, tt.signer.AuthType(), conf.Signer().AuthType())
+
+			// round trip
+			conf2, err := conf.ToPersistedConfig().toKwilCliConfig()
+			require.NoError(t, err)
+			assert.Equal(t, conf.Identity(), conf2.Identity())
+		})
+	}
+}
@@ -17,6 +17,7 @@ const (
 
 	// NOTE: these flags below are also used as viper key names
 	globalPrivateKeyFlag = "private-key"
+	globalKeyTypeFlag    = "key-type"
 	GlobalProviderFlag   = "provider"
 	globalChainIDFlag    = "chain-id"
 	globalConfigFileFlag = "config"
@@ -24,6 +25,7 @@ const (
 	// NOTE: viper key name are used for viper related operations
 	// here they are same `mapstructure` names defined in the config struct
 	viperPrivateKeyName = "private_key"
+	viperKeyTypeName    = "key_type"
 	viperProviderName   = "provider"
 	viperChainID        = "chain_id"
 	viperConfigFile     = "config"
@@ -47,12 +49,14 @@ func init() {
 func BindGlobalFlags(fs *pflag.FlagSet) {
 	// Bind flags to environment variables
 	fs.String(globalPrivateKeyFlag, cliCfg.PrivateKey, "the private key of the wallet that will be used for signing")
+	fs.String(globalKeyTypeFlag, cliCfg.KeyType, "the type of the private key: secp256k1 (default) or ed25519")
 	fs.String(GlobalProviderFlag, cliCfg.Provider, "the Kwil provider RPC endpoint")
 	fs.String(globalChainIDFlag, cliCfg.ChainID, "the expected/intended Kwil Chain ID")
 	fs.StringVar(&configFile, globalConfigFileFlag, defaultConfigFile, "the path to the Kwil CLI persistent global settings file")
 
 	// Bind flags to viper, named by the flag name
 	viper.BindPFlag(viperPrivateKeyName, fs.Lookup(globalPrivateKeyFlag))
+	viper.BindPFlag(viperKeyTypeName, fs.Lookup(globalKeyTypeFlag))
 	viper.BindPFlag(viperProviderName, fs.Lookup(GlobalProviderFlag))
 	viper.BindPFlag(viperChainID, fs.Lookup(globalChainIDFlag))
 
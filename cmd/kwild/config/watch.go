@@ -0,0 +1,167 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadableSettings are the config file settings that may be applied to a
+// running node without a restart. Changes to any other settings only take
+// effect after the node is restarted.
+var reloadableSettings = map[string]bool{
+	"log.level":                  true,
+	"log.rpc_level":              true,
+	"log.consensus_level":        true,
+	"log.db_level":               true,
+	"chain.p2p.persistent_peers": true,
+}
+
+// ConfigReload describes a change to the config file detected by
+// WatchConfigFile.
+type ConfigReload struct {
+	// Config is the newly loaded config file. Like the result of
+	// LoadConfigFile, it is not merged with any defaults, environment
+	// variables, or flags.
+	Config *KwildConfig
+	// Reloadable lists the changed settings, such as "log.level", that may be
+	// applied without restarting the node.
+	Reloadable []string
+	// RestartRequired lists the changed settings that only take effect after
+	// the node is restarted.
+	RestartRequired []string
+}
+
+// WatchConfigFile watches the config file at configPath, and loads it with
+// LoadConfigFile whenever it changes. If any settings differ from the
+// previously loaded file, onReload is called with the new config and the
+// changed settings, split into those that may be applied to the running node
+// and those that require a restart. It is up to the caller to apply the
+// reloadable settings. If the file cannot be loaded, onReload is called with
+// the error, and the next successful load is compared with the last good one.
+//
+// The file must exist when WatchConfigFile is called. The watch is stopped when
+// the context is canceled, and WatchConfigFile returns only after onReload is
+// no longer being called.
+func WatchConfigFile(ctx context.Context, configPath string, onReload func(*ConfigReload, error)) error {
+	cfgFilePath, err := filepath.Abs(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of config file: %w", err)
+	}
+
+	prev, err := LoadConfigFile(cfgFilePath)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, since many editors replace the
+	// file on save, which would end a watch on the file itself.
+	if err = watcher.Add(filepath.Dir(cfgFilePath)); err != nil {
+		return fmt.Errorf("failed to watch config file directory: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errors.New("config file watcher closed")
+			}
+			onReload(nil, err)
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return errors.New("config file watcher closed")
+			}
+			if ev.Name != cfgFilePath || !ev.Has(fsnotify.Write|fsnotify.Create) {
+				continue
+			}
+
+			cfg, err := LoadConfigFile(cfgFilePath)
+			if err != nil {
+				onReload(nil, err)
+				continue
+			}
+
+			changed := changedSettings(prev, cfg)
+			if len(changed) == 0 {
+				continue // e.g. multiple write events for one save
+			}
+			prev = cfg
+
+			reload := &ConfigReload{Config: cfg}
+			for _, key := range changed {
+				if reloadableSettings[key] {
+					reload.Reloadable = append(reload.Reloadable, key)
+				} else {
+					reload.RestartRequired = append(reload.RestartRequired, key)
+				}
+			}
+			onReload(reload, nil)
+		}
+	}
+}
+
+// changedSettings returns the sorted keys of the settings that differ between
+// two configs. Keys are named like in the config file, e.g. "app.hostname".
+func changedSettings(a, b *KwildConfig) []string {
+	var changed []string
+	diffSettings("", reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), &changed)
+	slices.Sort(changed)
+	return changed
+}
+
+func diffSettings(prefix string, a, b reflect.Value, changed *[]string) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, have := field.Tag.Lookup("mapstructure")
+		if !have {
+			if prefix == "" {
+				continue // not from the config file, e.g. RootDir
+			}
+			name = strings.ToLower(field.Name) // mapstructure's default
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		fa, fb := a.Field(i), b.Field(i)
+		if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+			fa, fb = derefOrZero(fa), derefOrZero(fb)
+		}
+
+		if fa.Kind() == reflect.Struct {
+			diffSettings(key, fa, fb, changed)
+			continue
+		}
+		if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			*changed = append(*changed, key)
+		}
+	}
+}
+
+// derefOrZero dereferences a pointer to a struct, or returns the zero struct if
+// the pointer is nil, as when a section is omitted from the config file.
+func derefOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.New(v.Type().Elem()).Elem()
+	}
+	return v.Elem()
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WatchConfigFile(t *testing.T) {
+	const origCfg = `
+[log]
+level = "info"
+
+[app]
+hostname = "kwil.local"
+`
+	const newCfg = `
+[log]
+level = "debug"
+
+[app]
+hostname = "kwil.remote"
+
+[chain.p2p]
+persistent_peers = "0c830b69790eaa09315826403c2008edc65b5c7c@127.0.0.1:26656"
+`
+	cfgPath := filepath.Join(t.TempDir(), ConfigFileName)
+	require.NoError(t, os.WriteFile(cfgPath, []byte(origCfg), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := make(chan *ConfigReload, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := WatchConfigFile(ctx, cfgPath, func(cr *ConfigReload, err error) {
+			if err != nil {
+				t.Errorf("unexpected reload error: %v", err)
+				return
+			}
+			select {
+			case reloads <- cr:
+			default:
+			}
+		})
+		assert.NoError(t, err)
+	}()
+
+	// The watch may not be established right away, so keep writing the new
+	// config until the change is seen.
+	var reload *ConfigReload
+	require.Eventually(t, func() bool {
+		require.NoError(t, os.WriteFile(cfgPath, []byte(newCfg), 0600))
+		select {
+		case reload = <-reloads:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "debug", reload.Config.Logging.Level)
+	assert.Equal(t, "kwil.remote", reload.Config.AppCfg.Hostname)
+	assert.Equal(t, "0c830b69790eaa09315826403c2008edc65b5c7c@127.0.0.1:26656", reload.Config.ChainCfg.P2P.PersistentPeers)
+	assert.Equal(t, []string{"chain.p2p.persistent_peers", "log.level"}, reload.Reloadable)
+	assert.Equal(t, []string{"app.hostname"}, reload.RestartRequired)
+
+	cancel()
+	wg.Wait()
+}
+
+func Test_changedSettings(t *testing.T) {
+	a := DefaultConfig()
+	b := DefaultConfig()
+	assert.Empty(t, changedSettings(a, b))
+
+	b.Logging.RPCLevel = "debug"
+	b.AppCfg.Snapshots.MaxSnapshots++
+	b.ChainCfg.P2P = nil
+	b.RootDir = "/elsewhere" // not a config file setting
+
+	changed := changedSettings(a, b)
+	assert.Contains(t, changed, "log.rpc_level")
+	assert.Contains(t, changed, "app.snapshots.max_snapshots")
+	assert.Contains(t, changed, "chain.p2p.listen_addr")
+	assert.NotContains(t, changed, "root_dir")
+}
@@ -212,8 +212,8 @@ func buildServer(d *coreDependencies, closers *closeFuncs) *Server {
 	listeners := buildListenerManager(d, ev, cometBftNode, txApp, db)
 
 	// user service and server
-	rpcSvcLogger := increaseLogLevel("user-json-svc", &d.log, d.cfg.Logging.RPCLevel)
-	rpcServerLogger := increaseLogLevel("user-jsonrpc-server", &d.log, d.cfg.Logging.RPCLevel)
+	rpcSvcLogger := reloadableLogger("user-json-svc", &d.log, d.logLevels.rpc)
+	rpcServerLogger := reloadableLogger("user-jsonrpc-server", &d.log, d.logLevels.rpc)
 
 	jsonRPCTxSvc := usersvc.NewService(db, e, wrappedCmtClient, txApp,
 		*rpcSvcLogger, usersvc.WithReadTxTimeout(time.Duration(d.cfg.AppCfg.ReadTxTimeout)))
@@ -249,6 +249,7 @@ func buildServer(d *coreDependencies, closers *closeFuncs) *Server {
 		log:                *d.log.Named("server"),
 		closers:            closers,
 		cfg:                d.cfg,
+		logLevels:          d.logLevels,
 		dbCtx:              db,
 	}
 }
@@ -306,6 +307,7 @@ type coreDependencies struct {
 	genesisCfg *chain.GenesisConfig
 	privKey    cmtEd.PrivKey
 	log        log.Logger
+	logLevels  *logLevels
 	dbOpener   dbOpener
 	poolOpener poolOpener
 	keypair    *tls.Certificate
@@ -880,7 +882,7 @@ func buildCometNode(d *coreDependencies, closer *closeFuncs, abciApp abciTypes.A
 		}
 	}
 
-	nodeLogger := increaseLogLevel("cometbft", &d.log, d.cfg.Logging.ConsensusLevel)
+	nodeLogger := reloadableLogger("cometbft", &d.log, d.logLevels.consensus)
 	node, err := cometbft.NewCometBftNode(d.ctx, abciApp, nodeCfg, genDoc, d.privKey,
 		readWriter, nodeLogger)
 	if err != nil {
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kwilteam/kwil-db/cmd/kwild/config"
+	"github.com/kwilteam/kwil-db/core/log"
+
+	"go.uber.org/zap"
+)
+
+// logLevels holds the levels of kwild's loggers, which may be changed while the
+// node is running when the log settings in the config file change.
+type logLevels struct {
+	root      log.AtomicLevel
+	rpc       log.AtomicLevel
+	consensus log.AtomicLevel
+	db        log.AtomicLevel
+}
+
+func newLogLevels() *logLevels {
+	return &logLevels{
+		root:      log.NewAtomicLevelAt(log.InfoLevel),
+		rpc:       log.NewAtomicLevelAt(log.InfoLevel),
+		consensus: log.NewAtomicLevelAt(log.InfoLevel),
+		db:        log.NewAtomicLevelAt(log.InfoLevel),
+	}
+}
+
+// set updates the levels from the log settings. The root level defaults to
+// info. An empty or invalid sub-level follows the root level, as does one that
+// is lower than the root level, since a sub-logger cannot log anything that the
+// root logger does not. Invalid levels are reported in the returned error, but
+// the valid ones are still applied.
+func (ll *logLevels) set(cfg *config.Logging) error {
+	var errs []error
+
+	root := log.InfoLevel
+	if cfg.Level != "" {
+		lvl, err := log.ParseLevel(cfg.Level)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid log level %q: %w", cfg.Level, err))
+			root = ll.root.Level() // unchanged
+		} else {
+			root = lvl
+		}
+	}
+	ll.root.SetLevel(root)
+
+	setSub := func(name string, sub log.AtomicLevel, level string) {
+		lvl := root
+		if level != "" {
+			parsed, err := log.ParseLevel(level)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s log level %q: %w", name, level, err))
+			} else if parsed > root {
+				lvl = parsed
+			}
+		}
+		sub.SetLevel(lvl)
+	}
+	setSub("rpc", ll.rpc, cfg.RPCLevel)
+	setSub("consensus", ll.consensus, cfg.ConsensusLevel)
+	setSub("db", ll.db, cfg.DBLevel)
+
+	return errors.Join(errs...)
+}
+
+// reloadableLogger creates a named logger whose level threshold follows the
+// given level, which should be one of the sub-levels in logLevels.
+func reloadableLogger(name string, logger *log.Logger, level log.AtomicLevel) *log.Logger {
+	return logger.Named(name).IncreasedAtomicLevel(level)
+}
+
+// applyConfigReload applies the reloadable settings that changed in the config
+// file to the running node, and warns about any that need a restart. Like the
+// settings in the file, they take precedence over any flags or environment
+// variables given at startup.
+func (s *Server) applyConfigReload(reload *config.ConfigReload, err error) {
+	if err != nil {
+		s.log.Warn("failed to reload config file", zap.Error(err))
+		return
+	}
+
+	cfg := reload.Config
+	logChanged := slices.ContainsFunc(reload.Reloadable, func(key string) bool {
+		return key != "chain.p2p.persistent_peers"
+	})
+	if logChanged {
+		logging := cfg.Logging
+		if logging == nil {
+			logging = &config.Logging{}
+		}
+		if err := s.logLevels.set(logging); err != nil {
+			s.log.Warn("invalid log levels in config file", zap.Error(err))
+		}
+		s.log.Info("applied log levels from config file", zap.String("level", s.logLevels.root.String()),
+			zap.String("rpc_level", s.logLevels.rpc.String()),
+			zap.String("consensus_level", s.logLevels.consensus.String()),
+			zap.String("db_level", s.logLevels.db.String()))
+	}
+
+	if slices.Contains(reload.Reloadable, "chain.p2p.persistent_peers") {
+		var peers string
+		if cfg.ChainCfg != nil && cfg.ChainCfg.P2P != nil {
+			peers = cfg.ChainCfg.P2P.PersistentPeers
+		}
+		if err := s.cometBftNode.SetPersistentPeers(peers); err != nil {
+			s.log.Warn("failed to apply persistent peers from config file", zap.Error(err))
+		} else {
+			s.log.Info("applied persistent peers from config file", zap.String("persistent_peers", peers))
+		}
+	}
+
+	if len(reload.RestartRequired) > 0 {
+		s.log.Warn("config file settings changed that require a restart",
+			zap.Strings("settings", reload.RestartRequired))
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/kwilteam/kwil-db/cmd/kwild/config"
+	"github.com/kwilteam/kwil-db/core/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func Test_logLevels(t *testing.T) {
+	levels := newLogLevels()
+	require.NoError(t, levels.set(&config.Logging{Level: "info", RPCLevel: "warn"}))
+
+	core, logs := observer.New(zap.DebugLevel)
+	root := (&log.Logger{L: zap.New(core)}).IncreasedAtomicLevel(levels.root)
+	rpc := reloadableLogger("rpc", root, levels.rpc)
+	db := reloadableLogger("db", root, levels.db)
+
+	rpc.Info("rpc info")
+	db.Debug("db debug")
+	db.Info("db info")
+	assert.Equal(t, []string{"db info"}, messages(logs))
+
+	// lower the root and rpc levels, db follows the root level
+	require.NoError(t, levels.set(&config.Logging{Level: "debug", RPCLevel: "info"}))
+	rpc.Debug("rpc debug")
+	rpc.Info("rpc info")
+	db.Debug("db debug")
+	assert.Equal(t, []string{"rpc info", "db debug"}, messages(logs))
+
+	// a sub-level cannot be lower than the root level
+	require.NoError(t, levels.set(&config.Logging{Level: "warn", RPCLevel: "debug"}))
+	assert.Equal(t, log.WarnLevel, levels.rpc.Level())
+	rpc.Info("rpc info")
+	assert.Empty(t, messages(logs))
+
+	// invalid levels are reported, and the valid ones are applied
+	err := levels.set(&config.Logging{Level: "error", DBLevel: "loud"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid db log level "loud"`)
+	assert.Equal(t, log.ErrorLevel, levels.root.Level())
+	assert.Equal(t, log.ErrorLevel, levels.db.Level())
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, entry := range logs.TakeAll() {
+		msgs = append(msgs, entry.Message)
+	}
+	return msgs
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"time"
 
@@ -44,7 +45,8 @@ type Server struct {
 		Err() error
 	}
 
-	cfg *config.KwildConfig
+	cfg       *config.KwildConfig
+	logLevels *logLevels
 
 	cancelCtxFunc context.CancelFunc
 }
@@ -59,11 +61,18 @@ const (
 // New builds the kwild server.
 func New(ctx context.Context, cfg *config.KwildConfig, genesisCfg *chain.GenesisConfig,
 	nodeKey *crypto.Ed25519PrivateKey, autogen bool) (svr *Server, err error) {
-	logger, err := log.NewChecked(*cfg.LogConfig())
+	logLevels := newLogLevels()
+	levelsErr := logLevels.set(cfg.Logging)
+	logCfg := cfg.LogConfig()
+	logCfg.AtomicLevel = &logLevels.root
+	logger, err := log.NewChecked(*logCfg)
 	if err != nil {
 		return nil, fmt.Errorf("invalid logger config: %w", err)
 	}
 	logger = *logger.Named("kwild")
+	if levelsErr != nil {
+		logger.Warn("ignoring invalid log levels", zap.Error(levelsErr))
+	}
 
 	closers := &closeFuncs{
 		closers: make([]func() error, 0),
@@ -100,7 +109,7 @@ func New(ctx context.Context, cfg *config.KwildConfig, genesisCfg *chain.Genesis
 		return nil, err
 	}
 
-	dbLogger := increaseLogLevel("pg", &logger, cfg.Logging.DBLevel)
+	dbLogger := reloadableLogger("pg", &logger, logLevels.db)
 	pg.UseLogger(*dbLogger)
 
 	host, port, user, pass := cfg.AppCfg.DBHost, cfg.AppCfg.DBPort, cfg.AppCfg.DBUser, cfg.AppCfg.DBPass
@@ -112,6 +121,7 @@ func New(ctx context.Context, cfg *config.KwildConfig, genesisCfg *chain.Genesis
 		genesisCfg: genesisCfg,
 		privKey:    ed25519.PrivKey(nodeKey.Bytes()),
 		log:        logger,
+		logLevels:  logLevels,
 		dbOpener:   newDBOpener(host, port, user, pass), // could make cfg.AppCfg.DBName baked into it this one too
 		poolOpener: newPoolBOpener(host, port, user, pass),
 		keypair:    keyPair,
@@ -203,6 +213,20 @@ func (s *Server) Start(ctx context.Context) error {
 	})
 	s.log.Info("listener manager started")
 
+	// Apply changes to the reloadable settings in the config file, if there is
+	// one, while the node is running.
+	cfgFile := filepath.Join(s.cfg.RootDir, config.ConfigFileName)
+	if _, err := os.Stat(cfgFile); err == nil {
+		group.Go(func() error {
+			err := config.WatchConfigFile(groupCtx, cfgFile, s.applyConfigReload)
+			if err != nil { // not fatal, the node just stops picking up changes
+				s.log.Warn("config file watcher stopped", zap.Error(err))
+			}
+			return nil
+		})
+		s.log.Info("watching config file for changes", zap.String("file", cfgFile))
+	}
+
 	group.Go(func() error {
 		// The CometBFT services do not block on Start().
 		if err := s.cometBftNode.Start(); err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kwilteam/kwil-db/core/log"
 	types "github.com/kwilteam/kwil-db/core/types/admin"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
 	"github.com/kwilteam/kwil-db/extensions/precompiles"
 	"github.com/kwilteam/kwil-db/internal/abci"
 	"github.com/kwilteam/kwil-db/internal/abci/cometbft/privval"
@@ -113,6 +114,31 @@ func (wc *wrappedCometBFTClient) Peers(ctx context.Context) ([]*types.PeerInfo,
 	return peers, nil
 }
 
+func (wc *wrappedCometBFTClient) UnconfirmedTxs(ctx context.Context, limit int) ([]*types.PendingTx, error) {
+	var limitPtr *int // nil for the cometbft default
+	if limit > 0 {
+		limitPtr = &limit
+	}
+	res, err := wc.cl.UnconfirmedTxs(ctx, limitPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*types.PendingTx, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = &types.PendingTx{
+			Hash: tx.Hash(),
+			Size: len(tx),
+		}
+		var decoded transactions.Transaction
+		if err := decoded.UnmarshalBinary(tx); err == nil && decoded.Body != nil {
+			txs[i].Sender = decoded.Sender
+			txs[i].Nonce = decoded.Body.Nonce
+		}
+	}
+	return txs, nil
+}
+
 func (wc *wrappedCometBFTClient) Status(ctx context.Context) (*types.Status, error) {
 	cmtStatus, err := wc.cl.Status(ctx)
 	if err != nil {
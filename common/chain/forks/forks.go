@@ -18,6 +18,21 @@ const (
 	// new network, which is accomplished via the globally available IsHalt and
 	// BeginsHalt methods.
 	ForkHalt = "halt"
+
+	// ForkStrictSigs makes the secp256k1 personal sign authenticator reject
+	// signatures with a high S value. Before activation, both S values are
+	// accepted, as they were when the network was started.
+	ForkStrictSigs = "strict_sigs"
+
+	// ForkTxExpiry enables the ValidUntilHeight field of transaction bodies.
+	// Nodes that predate the field cannot decode a transaction that sets it,
+	// so such transactions are refused until this fork activates.
+	ForkTxExpiry = "tx_expiry"
+
+	// ForkValidatorPower enables the validator_power payload, with which
+	// validators vote to change the power of an existing validator. Nodes that
+	// predate it reject the payload type, so it is refused until activation.
+	ForkValidatorPower = "validator_power"
 )
 
 // Forks lists the recognized hardforks and their activation heights or times,
@@ -162,6 +177,13 @@ func (fs *Forks) ForkHeight(fork string) *uint64 {
 	return fs.Extended[fork]
 }
 
+// IsActivated returns true if the named fork is in effect *as of* the given
+// height.
+func (fs *Forks) IsActivated(fork string, height uint64) bool {
+	ah := fs.ForkHeight(fork)
+	return ah != nil && height >= *ah
+}
+
 // String displays a human readable summary of all defined forks and their
 // activation heights. For example:
 //
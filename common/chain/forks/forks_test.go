@@ -156,6 +156,11 @@ func TestForks_Height(t *testing.T) {
 
 	hp = fs.ForkHeight("unknown")
 	require.Nil(t, hp)
+
+	assert.False(t, fs.IsActivated("extended", 5))
+	assert.True(t, fs.IsActivated("extended", 6))
+	assert.True(t, fs.IsActivated(forks.ForkHalt, 11))
+	assert.False(t, fs.IsActivated("unknown", 100))
 }
 
 func TestForks_FromMap(t *testing.T) {
@@ -0,0 +1,120 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kwilteam/kwil-db/common"
+)
+
+// NetworkParameters returns the network parameters that are persisted by the
+// application, as specified by these consensus parameters.
+func (cp *ConsensusParams) NetworkParameters() *common.NetworkParameters {
+	return &common.NetworkParameters{
+		MaxBlockSize:     cp.Block.MaxBytes,
+		JoinExpiry:       cp.Validator.JoinExpiry,
+		VoteExpiry:       cp.Votes.VoteExpiry,
+		DisabledGasCosts: cp.WithoutGasCosts,
+	}
+}
+
+// ParseGenesisParams parses the consensus params in a genesis.json document
+// into the network parameters that are stored on the first run of a new
+// network. Unlike LoadGenesisConfig, the parameters are validated, and each of
+// them must be explicitly set.
+func ParseGenesisParams(data []byte) (*common.NetworkParameters, error) {
+	// Use pointers to distinguish missing fields from zero values.
+	var genesis struct {
+		ConsensusParams *struct {
+			Block *struct {
+				MaxBytes *int64 `json:"max_bytes"`
+			} `json:"block"`
+			Validator *struct {
+				JoinExpiry *int64 `json:"join_expiry"`
+			} `json:"validator"`
+			Votes *struct {
+				VoteExpiry *int64 `json:"vote_expiry"`
+			} `json:"votes"`
+			WithoutGasCosts *bool `json:"without_gas_costs"`
+		} `json:"consensus_params"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return nil, fmt.Errorf("invalid genesis document: %w", err)
+	}
+
+	cp := genesis.ConsensusParams
+	if cp == nil {
+		return nil, errors.New("missing consensus_params")
+	}
+
+	var errs []error
+	params := &common.NetworkParameters{}
+
+	switch {
+	case cp.Block == nil || cp.Block.MaxBytes == nil:
+		errs = append(errs, errors.New("missing consensus_params.block.max_bytes"))
+	default:
+		params.MaxBlockSize = *cp.Block.MaxBytes
+		if err := checkMaxBlockSize(params.MaxBlockSize); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch {
+	case cp.Validator == nil || cp.Validator.JoinExpiry == nil:
+		errs = append(errs, errors.New("missing consensus_params.validator.join_expiry"))
+	default:
+		params.JoinExpiry = *cp.Validator.JoinExpiry
+		if err := checkExpiry("consensus_params.validator.join_expiry", params.JoinExpiry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch {
+	case cp.Votes == nil || cp.Votes.VoteExpiry == nil:
+		errs = append(errs, errors.New("missing consensus_params.votes.vote_expiry"))
+	default:
+		params.VoteExpiry = *cp.Votes.VoteExpiry
+		if err := checkExpiry("consensus_params.votes.vote_expiry", params.VoteExpiry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if cp.WithoutGasCosts == nil {
+		errs = append(errs, errors.New("missing consensus_params.without_gas_costs"))
+	} else {
+		params.DisabledGasCosts = *cp.WithoutGasCosts
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return params, nil
+}
+
+// ValidateNetworkParameters checks that the network parameters from genesis
+// are usable: the maximum block size must be positive, and the join and vote
+// expiries must not be negative. All problems are reported at once.
+func ValidateNetworkParameters(params *common.NetworkParameters) error {
+	return errors.Join(
+		checkMaxBlockSize(params.MaxBlockSize),
+		checkExpiry("consensus_params.validator.join_expiry", params.JoinExpiry),
+		checkExpiry("consensus_params.votes.vote_expiry", params.VoteExpiry),
+	)
+}
+
+func checkMaxBlockSize(maxBytes int64) error {
+	if maxBytes <= 0 {
+		return fmt.Errorf("consensus_params.block.max_bytes must be positive, got %d", maxBytes)
+	}
+	return nil
+}
+
+func checkExpiry(name string, expiry int64) error {
+	if expiry < 0 {
+		return fmt.Errorf("%s must not be negative, got %d", name, expiry)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGenesisParams(t *testing.T) {
+	t.Run("default genesis", func(t *testing.T) {
+		gc := DefaultGenesisConfig()
+		data, err := json.Marshal(gc)
+		require.NoError(t, err)
+
+		params, err := ParseGenesisParams(data)
+		require.NoError(t, err)
+		assert.Equal(t, gc.ConsensusParams.NetworkParameters(), params)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		params, err := ParseGenesisParams([]byte(`{"consensus_params": {
+			"block": {"max_bytes": 1024},
+			"validator": {"join_expiry": 0},
+			"votes": {"vote_expiry": 10},
+			"without_gas_costs": false
+		}}`))
+		require.NoError(t, err)
+		assert.Equal(t, &common.NetworkParameters{
+			MaxBlockSize: 1024,
+			JoinExpiry:   0,
+			VoteExpiry:   10,
+		}, params)
+	})
+
+	invalid := []struct {
+		name    string
+		genesis string
+		errMsg  string
+	}{
+		{"not json", `{`, "invalid genesis document"},
+		{"no consensus params", `{"chain_id": "kwil-chain-1"}`, "missing consensus_params"},
+		{"negative block size", `{"consensus_params": {
+			"block": {"max_bytes": -1},
+			"validator": {"join_expiry": 1},
+			"votes": {"vote_expiry": 1},
+			"without_gas_costs": true
+		}}`, "consensus_params.block.max_bytes must be positive, got -1"},
+		{"zero block size", `{"consensus_params": {
+			"block": {"max_bytes": 0},
+			"validator": {"join_expiry": 1},
+			"votes": {"vote_expiry": 1},
+			"without_gas_costs": true
+		}}`, "consensus_params.block.max_bytes must be positive"},
+		{"negative join expiry", `{"consensus_params": {
+			"block": {"max_bytes": 1},
+			"validator": {"join_expiry": -5},
+			"votes": {"vote_expiry": 1},
+			"without_gas_costs": true
+		}}`, "consensus_params.validator.join_expiry must not be negative"},
+		{"negative vote expiry", `{"consensus_params": {
+			"block": {"max_bytes": 1},
+			"validator": {"join_expiry": 1},
+			"votes": {"vote_expiry": -5},
+			"without_gas_costs": true
+		}}`, "consensus_params.votes.vote_expiry must not be negative"},
+		{"missing vote expiry", `{"consensus_params": {
+			"block": {"max_bytes": 1},
+			"validator": {"join_expiry": 1},
+			"votes": {},
+			"without_gas_costs": true
+		}}`, "missing consensus_params.votes.vote_expiry"},
+		{"missing gas costs", `{"consensus_params": {
+			"block": {"max_bytes": 1},
+			"validator": {"join_expiry": 1},
+			"votes": {"vote_expiry": 1}
+		}}`, "missing consensus_params.without_gas_costs"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGenesisParams([]byte(tt.genesis))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	// All problems are reported, not just the first.
+	_, err := ParseGenesisParams([]byte(`{"consensus_params": {"block": {"max_bytes": -1}}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_bytes must be positive")
+	assert.Contains(t, err.Error(), "missing consensus_params.validator.join_expiry")
+	assert.Contains(t, err.Error(), "missing consensus_params.votes.vote_expiry")
+}
+
+func TestValidateNetworkParameters(t *testing.T) {
+	require.NoError(t, ValidateNetworkParameters(DefaultGenesisConfig().ConsensusParams.NetworkParameters()))
+	require.NoError(t, ValidateNetworkParameters(&common.NetworkParameters{MaxBlockSize: 1}))
+
+	err := ValidateNetworkParameters(&common.NetworkParameters{
+		MaxBlockSize: 0,
+		JoinExpiry:   -1,
+		VoteExpiry:   -2,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "consensus_params.block.max_bytes must be positive, got 0")
+	assert.Contains(t, err.Error(), "consensus_params.validator.join_expiry must not be negative, got -1")
+	assert.Contains(t, err.Error(), "consensus_params.votes.vote_expiry must not be negative, got -2")
+}
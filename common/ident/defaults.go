@@ -15,7 +15,10 @@ func init() {
 		panic(err)
 	}
 
-	err = authExt.RegisterAuthenticator(authExt.ModAdd, auth.EthPersonalSignAuth, auth.EthSecp256k1Authenticator{})
+	// High-S signatures remain valid until the "strict_sigs" hardfork, since
+	// rejecting them would change the result of already committed transactions.
+	err = authExt.RegisterAuthenticator(authExt.ModAdd, auth.EthPersonalSignAuth,
+		auth.EthSecp256k1Authenticator{AllowHighS: true})
 	if err != nil {
 		panic(err)
 	}
@@ -20,7 +20,6 @@ import (
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
 	"github.com/kwilteam/kwil-db/core/types/transactions"
-	"github.com/kwilteam/kwil-db/core/utils"
 	"go.uber.org/zap"
 )
 
@@ -45,6 +44,11 @@ func (c *Client) SvcClient() user.TxSvcClient {
 
 var _ clientType.Client = (*Client)(nil)
 
+// ErrChainIDMismatch is returned when the chain ID of the remote host is not
+// the chain ID the client was configured with. Transactions signed for one
+// chain are not valid on another, so the client refuses to use the host.
+var ErrChainIDMismatch = errors.New("chain ID mismatch")
+
 // NewClient creates a Kwil client. The target should be a URL (for an
 // http.Client). It by default communicates with target via HTTP; chain ID of the
 // remote host will be verified against the chain ID passed in.
@@ -113,7 +117,8 @@ func WrapClient(ctx context.Context, client user.TxSvcClient, options *clientTyp
 		}
 		c.chainID = chainID
 	} else if c.chainID != chainID {
-		return nil, fmt.Errorf("remote host chain ID %q != client configured %q", chainID, c.chainID)
+		return nil, fmt.Errorf("%w: remote host chain ID %q != client configured %q",
+			ErrChainIDMismatch, chainID, c.chainID)
 	}
 
 	return c, nil
@@ -195,7 +200,7 @@ func (c *Client) DeployDatabase(ctx context.Context, payload *types.Schema, opts
 // DropDatabase drops a database by name, using the configured signer to derive
 // the DB ID.
 func (c *Client) DropDatabase(ctx context.Context, name string, opts ...clientType.TxOpt) (transactions.TxHash, error) {
-	dbid := utils.GenerateDBID(name, c.Signer.Identity())
+	dbid := types.GenerateDBID(name, c.Signer.Identity())
 	return c.DropDatabaseID(ctx, dbid, opts...)
 }
 
@@ -300,8 +305,40 @@ func (c *Client) Call(ctx context.Context, dbid string, procedure string, inputs
 	return clientType.NewRecordsFromMaps(res), nil
 }
 
-// Query executes a query.
-func (c *Client) Query(ctx context.Context, dbid string, query string) (*clientType.Records, error) {
+// ActionSignature gets the signature of an action or procedure in a database,
+// which are the names and types of the parameters in the order they are
+// passed. This may be used to check inputs before calling or executing it.
+func (c *Client) ActionSignature(ctx context.Context, dbid string, action string) (*types.ActionSignature, error) {
+	schema, err := c.txClient.GetSchema(ctx, dbid)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, found := schema.ActionSignature(action)
+	if !found {
+		return nil, fmt.Errorf("action or procedure %q not found in database %s", action, dbid)
+	}
+	return sig, nil
+}
+
+// Query executes a query. Unless the AllowMutatingQuery option is given, the
+// query is checked before it is sent, and statements that would modify the
+// database, or multiple statements, are rejected with ErrMutatingQuery. Such
+// queries would fail on the node, and usually mean that Execute was intended.
+func (c *Client) Query(ctx context.Context, dbid string, query string, opts ...clientType.QueryOpt) (*clientType.Records, error) {
+	queryOpts := clientType.GetQueryOpts(opts)
+	if !queryOpts.AllowMutating {
+		if err := checkReadOnlyQuery(query); err != nil {
+			return nil, err
+		}
+	}
+
+	if queryOpts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, queryOpts.Timeout)
+		defer cancel()
+	}
+
 	res, err := c.txClient.Query(ctx, dbid, query)
 	if err != nil {
 		return nil, err
@@ -327,6 +364,26 @@ func (c *Client) GetAccount(ctx context.Context, acctID []byte, status types.Acc
 	return c.txClient.GetAccount(ctx, acctID, status)
 }
 
+// GetAccountStates gets both the latest (confirmed) and pending account info by
+// account ID. The states are requested one after the other, so they are not a
+// consistent snapshot: a block may be committed in between, in which case the
+// latest state is from the previous height, and the pending state may not
+// include the transactions that were still in mempool at that height.
+func (c *Client) GetAccountStates(ctx context.Context, acctID []byte) (*types.AccountStates, error) {
+	latest, err := c.txClient.GetAccount(ctx, acctID, types.AccountStatusLatest)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := c.txClient.GetAccount(ctx, acctID, types.AccountStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AccountStates{
+		Latest:  latest,
+		Pending: pending,
+	}, nil
+}
+
 // encodeTuple encodes a tuple for usage in a transaction.
 func encodeTuple(tup []any) ([]*transactions.EncodedValue, error) {
 	encoded := make([]*transactions.EncodedValue, 0, len(tup))
@@ -374,6 +431,54 @@ func (c *Client) WaitTx(ctx context.Context, txHash []byte, interval time.Durati
 	}
 }
 
+// WaitConfirmations waits for a transaction to be included in a block, like
+// WaitTx, and then for the chain to reach the given number of confirmations,
+// which is when the best block height is at least the inclusion height plus
+// confirmations. Both are polled at the given interval. The transaction is
+// queried again when the confirmations are reached, which also ensures it is
+// still included in a block.
+func (c *Client) WaitConfirmations(ctx context.Context, txHash []byte, confirmations int64, interval time.Duration) (*transactions.TcTxQueryResponse, error) {
+	if confirmations < 0 {
+		return nil, errors.New("confirmations must not be negative")
+	}
+
+	resp, err := c.WaitTx(ctx, txHash, interval)
+	if err != nil || confirmations == 0 {
+		return resp, err
+	}
+
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		info, err := c.txClient.ChainInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if int64(info.BlockHeight) >= resp.Height+confirmations {
+			break
+		}
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	resp, err = c.TxQuery(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Height <= 0 {
+		return nil, errors.New("transaction is no longer included in a block")
+	}
+	return resp, nil
+}
+
+// Validators gets the current validator set.
+func (c *Client) Validators(ctx context.Context) ([]*types.Validator, error) {
+	return c.txClient.Validators(ctx)
+}
+
 // ChainID returns the configured chain ID.
 func (c *Client) ChainID() string {
 	return c.chainID
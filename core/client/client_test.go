@@ -1 +1,267 @@
 package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/kwilteam/kwil-db/core/rpc/client/user"
+	"github.com/kwilteam/kwil-db/core/types"
+	clientType "github.com/kwilteam/kwil-db/core/types/client"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chainInfoClient is a user.TxSvcClient that only implements ChainInfo.
+type chainInfoClient struct {
+	user.TxSvcClient
+	chainID string
+}
+
+func (c *chainInfoClient) ChainInfo(ctx context.Context) (*types.ChainInfo, error) {
+	return &types.ChainInfo{ChainID: c.chainID}, nil
+}
+
+func TestWrapClient_ChainID(t *testing.T) {
+	ctx := context.Background()
+	svc := &chainInfoClient{chainID: "kwil-chain-a"}
+
+	key, err := crypto.GenerateSecp256k1Key()
+	require.NoError(t, err)
+	signer := &auth.EthPersonalSigner{Key: *key}
+
+	t.Run("matching", func(t *testing.T) {
+		cl, err := WrapClient(ctx, svc, &clientType.Options{ChainID: "kwil-chain-a", Signer: signer})
+		require.NoError(t, err)
+		assert.Equal(t, "kwil-chain-a", cl.ChainID())
+
+		// The chain ID is in the signed body, so the transaction is not valid
+		// for another chain.
+		tx, err := cl.NewSignedTx(ctx, &transactions.DropSchema{DBID: "xdbid"},
+			&clientType.TxOptions{Nonce: 1, Fee: big.NewInt(0)})
+		require.NoError(t, err)
+		assert.Equal(t, "kwil-chain-a", tx.Body.ChainID)
+		require.NoError(t, tx.Verify())
+
+		tx.Body.ChainID = "kwil-chain-b"
+		require.Error(t, tx.Verify())
+	})
+
+	t.Run("mismatched", func(t *testing.T) {
+		_, err := WrapClient(ctx, svc, &clientType.Options{ChainID: "kwil-chain-b", Signer: signer})
+		require.ErrorIs(t, err, ErrChainIDMismatch)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		cl, err := WrapClient(ctx, svc, &clientType.Options{Signer: signer, Silence: true})
+		require.NoError(t, err)
+		assert.Equal(t, "kwil-chain-a", cl.ChainID())
+	})
+}
+
+// accountClient is a user.TxSvcClient that only implements ChainInfo and
+// GetAccount, with a pending transaction in mempool.
+type accountClient struct {
+	chainInfoClient
+}
+
+func (c *accountClient) GetAccount(ctx context.Context, acctID []byte, status types.AccountStatus) (*types.Account, error) {
+	if status == types.AccountStatusPending {
+		return &types.Account{Identifier: acctID, Balance: big.NewInt(90), Nonce: 3}, nil
+	}
+	return &types.Account{Identifier: acctID, Balance: big.NewInt(100), Nonce: 2}, nil
+}
+
+func TestClient_GetAccountStates(t *testing.T) {
+	ctx := context.Background()
+	svc := &accountClient{chainInfoClient{chainID: "kwil-chain-a"}}
+	cl, err := WrapClient(ctx, svc, &clientType.Options{ChainID: "kwil-chain-a"})
+	require.NoError(t, err)
+
+	acctID := []byte{1, 2, 3}
+	accts, err := cl.GetAccountStates(ctx, acctID)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Account{Identifier: acctID, Balance: big.NewInt(100), Nonce: 2}, accts.Latest)
+	assert.Equal(t, &types.Account{Identifier: acctID, Balance: big.NewInt(90), Nonce: 3}, accts.Pending)
+}
+
+// queryClient is a user.TxSvcClient that only implements Query, recording the
+// queries it receives.
+type queryClient struct {
+	user.TxSvcClient
+	queries  []string
+	deadline bool
+}
+
+func (c *queryClient) Query(ctx context.Context, dbid, query string) ([]map[string]any, error) {
+	c.queries = append(c.queries, query)
+	_, c.deadline = ctx.Deadline()
+	return []map[string]any{{"id": int64(1)}}, nil
+}
+
+func TestClient_Query(t *testing.T) {
+	ctx := context.Background()
+	svc := &queryClient{}
+	cl := &Client{txClient: svc}
+
+	_, err := cl.Query(ctx, "xdbid", "UPDATE users SET name = 'x'")
+	require.ErrorIs(t, err, ErrMutatingQuery)
+	assert.Empty(t, svc.queries)
+
+	recs, err := cl.Query(ctx, "xdbid", "SELECT id FROM users")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{{"id": int64(1)}}, recs.Export())
+	assert.False(t, svc.deadline)
+
+	_, err = cl.Query(ctx, "xdbid", "UPDATE users SET name = 'x'", clientType.AllowMutatingQuery())
+	require.NoError(t, err)
+	assert.Len(t, svc.queries, 2)
+
+	_, err = cl.Query(ctx, "xdbid", "SELECT 1", clientType.WithQueryTimeout(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, svc.deadline)
+}
+
+func Test_checkReadOnlyQuery(t *testing.T) {
+	allowed := []string{
+		"SELECT id FROM users",
+		"  select * from users where name = 'DELETE'",
+		"SELECT id FROM users;",
+		`SELECT "update" FROM users`,
+		"SELECT 'a;b' FROM users",
+		"SELECT 'it''s; DROP' FROM users",
+		"-- DELETE FROM users\nSELECT 1",
+		"/* ; */ SELECT 1",
+		"WITH t AS (SELECT 1) SELECT * FROM t",
+		"",
+	}
+	for _, q := range allowed {
+		assert.NoError(t, checkReadOnlyQuery(q), q)
+	}
+
+	rejected := []string{
+		"UPDATE users SET name = 'x'",
+		"insert into users values (1)",
+		"DELETE FROM users",
+		"CREATE TABLE t (id int)",
+		"drop table users",
+		"ALTER TABLE users ADD COLUMN x int",
+		"/* comment */ TRUNCATE users",
+		"SELECT 1; SELECT 2",
+		"SELECT 1; DELETE FROM users",
+		"WITH d AS (DELETE FROM users RETURNING id) SELECT * FROM d",
+	}
+	for _, q := range rejected {
+		assert.ErrorIs(t, checkReadOnlyQuery(q), ErrMutatingQuery, q)
+	}
+}
+
+// confirmClient is a user.TxSvcClient that includes a transaction at height 5
+// and advances the best block height by one on each ChainInfo call.
+type confirmClient struct {
+	user.TxSvcClient
+	height int64
+}
+
+func (c *confirmClient) ChainInfo(ctx context.Context) (*types.ChainInfo, error) {
+	c.height++
+	return &types.ChainInfo{BlockHeight: uint64(c.height)}, nil
+}
+
+func (c *confirmClient) TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTxQueryResponse, error) {
+	if c.height < 5 {
+		c.height = 5 // included on the first query
+	}
+	return &transactions.TcTxQueryResponse{Hash: txHash, Height: 5}, nil
+}
+
+func TestClient_WaitConfirmations(t *testing.T) {
+	ctx := context.Background()
+	const interval = 10 * time.Millisecond
+
+	svc := &confirmClient{}
+	cl := &Client{txClient: svc}
+	start := time.Now()
+	resp, err := cl.WaitConfirmations(ctx, []byte{1}, 3, interval)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Height)
+	assert.Equal(t, int64(8), svc.height)
+	// Heights 6, 7, and 8 are each seen one interval apart.
+	assert.GreaterOrEqual(t, time.Since(start), 2*interval)
+
+	// Zero confirmations is just inclusion.
+	svc = &confirmClient{}
+	cl = &Client{txClient: svc}
+	resp, err = cl.WaitConfirmations(ctx, []byte{1}, 0, interval)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Height)
+	assert.Equal(t, int64(5), svc.height)
+
+	_, err = cl.WaitConfirmations(ctx, []byte{1}, -1, interval)
+	require.Error(t, err)
+
+	// The context cancels the wait.
+	ctx, cancel := context.WithTimeout(ctx, 5*interval)
+	defer cancel()
+	_, err = cl.WaitConfirmations(ctx, []byte{1}, 1000, interval)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// schemaClient is a user.TxSvcClient that only implements GetSchema.
+type schemaClient struct {
+	user.TxSvcClient
+	schema *types.Schema
+}
+
+func (c *schemaClient) GetSchema(ctx context.Context, dbid string) (*types.Schema, error) {
+	return c.schema, nil
+}
+
+func TestClient_ActionSignature(t *testing.T) {
+	ctx := context.Background()
+	cl := &Client{txClient: &schemaClient{schema: &types.Schema{
+		Name: "db",
+		Actions: []*types.Action{{
+			Name:       "create_user",
+			Parameters: []string{"$id", "$name"},
+			Public:     true,
+		}},
+		Procedures: []*types.Procedure{{
+			Name: "get_user",
+			Parameters: []*types.ProcedureParameter{
+				{Name: "$id", Type: types.IntType},
+				{Name: "$tags", Type: types.ArrayType(types.TextType)},
+			},
+		}},
+	}}}
+
+	sig, err := cl.ActionSignature(ctx, "xdbid", "CREATE_USER")
+	require.NoError(t, err)
+	assert.Equal(t, &types.ActionSignature{
+		Name:   "create_user",
+		Public: true,
+		Parameters: []*types.ActionParameter{
+			{Name: "$id", Nullable: true},
+			{Name: "$name", Nullable: true},
+		},
+	}, sig)
+
+	sig, err = cl.ActionSignature(ctx, "xdbid", "get_user")
+	require.NoError(t, err)
+	assert.Equal(t, &types.ActionSignature{
+		Name:        "get_user",
+		IsProcedure: true,
+		Parameters: []*types.ActionParameter{
+			{Name: "$id", Type: types.IntType, Nullable: true},
+			{Name: "$tags", Type: types.ArrayType(types.TextType), Nullable: true},
+		},
+	}, sig)
+
+	_, err = cl.ActionSignature(ctx, "xdbid", "missing")
+	require.Error(t, err)
+}
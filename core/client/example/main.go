@@ -14,7 +14,6 @@ import (
 	"github.com/kwilteam/kwil-db/core/types"
 	ctypes "github.com/kwilteam/kwil-db/core/types/client"
 	"github.com/kwilteam/kwil-db/core/types/transactions"
-	"github.com/kwilteam/kwil-db/core/utils"
 	"github.com/kwilteam/kwil-db/parse"
 )
 
@@ -85,7 +84,7 @@ func main() {
 
 	// Deploy a Kuneiform schema called "was_here".
 	dbName := "was_here"
-	dbid := utils.GenerateDBID(dbName, acctID) // derive DBID
+	dbid := types.GenerateDBID(dbName, acctID) // derive DBID
 
 	// See if it already deployed.
 	deployed := slices.ContainsFunc(datasets, func(d *types.DatasetIdentifier) bool {
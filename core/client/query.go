@@ -0,0 +1,109 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrMutatingQuery is returned by Query when the statement would modify the
+// database, or when there is more than one statement. Ad-hoc queries are
+// read-only, so Execute should be used instead.
+var ErrMutatingQuery = errors.New("query is not a read-only statement")
+
+// mutatingKeywords are the leading keywords of statements that modify the
+// database or its schema.
+var mutatingKeywords = map[string]bool{
+	"INSERT":   true,
+	"UPDATE":   true,
+	"DELETE":   true,
+	"UPSERT":   true,
+	"MERGE":    true,
+	"CREATE":   true,
+	"ALTER":    true,
+	"DROP":     true,
+	"TRUNCATE": true,
+	"GRANT":    true,
+	"REVOKE":   true,
+}
+
+// checkReadOnlyQuery rejects queries that are obviously not read-only: those
+// with more than one statement, those starting with a mutating keyword, and
+// WITH queries that contain an INSERT, UPDATE, or DELETE. This is a
+// best-effort check to catch mistakes, not a SQL parser. The node enforces
+// the actual rules.
+func checkReadOnlyQuery(query string) error {
+	var stmts []string
+	for _, stmt := range strings.Split(stripLiteralsAndComments(query), ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	if len(stmts) > 1 {
+		return fmt.Errorf("%w: found %d statements", ErrMutatingQuery, len(stmts))
+	}
+	if len(stmts) == 0 {
+		return nil // let the node report it
+	}
+
+	words := strings.FieldsFunc(strings.ToUpper(stmts[0]), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	})
+	if len(words) == 0 {
+		return nil
+	}
+	if mutatingKeywords[words[0]] {
+		return fmt.Errorf("%w: %s statement", ErrMutatingQuery, words[0])
+	}
+	if words[0] == "WITH" {
+		for _, word := range words[1:] {
+			switch word {
+			case "INSERT", "UPDATE", "DELETE":
+				return fmt.Errorf("%w: WITH clause contains %s", ErrMutatingQuery, word)
+			}
+		}
+	}
+	return nil
+}
+
+// stripLiteralsAndComments replaces string literals, quoted identifiers, and
+// comments in a SQL statement with a space, so that keywords and semicolons
+// within them are not mistaken for part of the statement.
+func stripLiteralsAndComments(query string) string {
+	var sb strings.Builder
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			// A doubled quote is an escaped quote, which is handled as the end
+			// of one literal followed by the start of another.
+			end := strings.IndexByte(query[i+1:], c)
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end + 1
+			}
+			sb.WriteByte(' ')
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end
+			}
+			sb.WriteByte(' ')
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -1,11 +1,16 @@
 package auth_test
 
 import (
+	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/kwilteam/kwil-db/core/crypto"
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -57,10 +62,50 @@ func Test_Auth(t *testing.T) {
 			address, err := tc.authenticator.Identifier(tc.signer.Identity())
 			assert.NoError(t, err)
 			assert.Equal(t, tc.address, address)
+
+			// the signer's identifier should match without picking the
+			// authenticator by hand
+			address, err = auth.SignerIdentifier(tc.signer)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.address, address)
 		})
 	}
 }
 
+func Test_EthSecp256k1Authenticator_HighS(t *testing.T) {
+	signer := newEthSigner(secp256k1Key)
+	msg := []byte("foo")
+
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+
+	// (R, N-S) with the flipped recovery ID recovers the same address.
+	highSig := slices.Clone(sig.Signature)
+	s := new(big.Int).SetBytes(highSig[32:64])
+	new(big.Int).Sub(ethCrypto.S256().Params().N, s).FillBytes(highSig[32:64])
+	highSig[64] ^= 1
+
+	authn := auth.EthSecp256k1Authenticator{}
+	require.NoError(t, authn.Verify(signer.Identity(), msg, slices.Clone(sig.Signature)))
+
+	err = authn.Verify(signer.Identity(), msg, slices.Clone(highSig))
+	require.ErrorIs(t, err, crypto.ErrSignatureHighS)
+
+	legacyAuthn := auth.EthSecp256k1Authenticator{AllowHighS: true}
+	require.NoError(t, legacyAuthn.Verify(signer.Identity(), msg, slices.Clone(highSig)))
+}
+
+type unknownSigner struct {
+	auth.Signer
+}
+
+func (unknownSigner) AuthType() string { return "unknown" }
+
+func Test_SignerIdentifier_Unsupported(t *testing.T) {
+	_, err := auth.SignerIdentifier(unknownSigner{newEthSigner(secp256k1Key)})
+	assert.Error(t, err)
+}
+
 func newEthSigner(pkey string) *auth.EthPersonalSigner {
 	secpKey, err := crypto.Secp256k1PrivateKeyFromHex(pkey)
 	if err != nil {
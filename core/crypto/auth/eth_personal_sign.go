@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/kwilteam/kwil-db/core/crypto"
+
 	ethAccounts "github.com/ethereum/go-ethereum/accounts"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -24,7 +26,14 @@ const (
 // EthSecp256k1Authenticator is the authenticator for the Ethereum "personal
 // sign" signature type, which is the default signer for Kwil. As such, it is a
 // default authenticator.
-type EthSecp256k1Authenticator struct{}
+type EthSecp256k1Authenticator struct {
+	// AllowHighS permits non-canonical signatures with a high S value, which
+	// are otherwise rejected with crypto.ErrSignatureHighS. kwild allows them
+	// until the "strict_sigs" hardfork activates so that transactions committed
+	// before then still verify. Wallets and the EthPersonalSigner produce
+	// low-S signatures.
+	AllowHighS bool
+}
 
 var _ Authenticator = EthSecp256k1Authenticator{}
 
@@ -35,13 +44,19 @@ func (EthSecp256k1Authenticator) Identifier(ident []byte) (string, error) {
 }
 
 // Verify verifies applies the Ethereum TextHash digest and verifies the signature
-func (EthSecp256k1Authenticator) Verify(identity []byte, msg []byte, signature []byte) error {
+func (e EthSecp256k1Authenticator) Verify(identity []byte, msg []byte, signature []byte) error {
 	// signature is 65 bytes, [R || S || V] format
 	if len(signature) != ethPersonalSignSignatureLength {
 		return fmt.Errorf("invalid signature length: expected %d, received %d",
 			ethPersonalSignSignatureLength, len(signature))
 	}
 
+	// Unlike VerifySignature, Ecrecover accepts either S value, so reject the
+	// malleable high-S form here.
+	if !e.AllowHighS && !crypto.Secp256k1SignatureIsLowS(signature) {
+		return fmt.Errorf("invalid signature: %w", crypto.ErrSignatureHighS)
+	}
+
 	if signature[ethCrypto.RecoveryIDOffset] == 27 ||
 		signature[ethCrypto.RecoveryIDOffset] == 28 {
 		// Transform yellow paper V from 27/28 to 0/1
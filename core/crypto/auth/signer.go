@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/kwilteam/kwil-db/core/crypto"
 
 	ethAccount "github.com/ethereum/go-ethereum/accounts"
@@ -112,3 +114,18 @@ func (e *Ed25519Signer) Identity() []byte {
 func (e *Ed25519Signer) AuthType() string {
 	return Ed25519Auth
 }
+
+// SignerIdentifier returns the string identifier of the Signer's Identity,
+// derived by the Authenticator for the Signer's AuthType. This is the same
+// identifier that the engine will use as the `@caller` for transactions signed
+// by this Signer. Only the Signers defined in this package are supported.
+func SignerIdentifier(s Signer) (string, error) {
+	switch authType := s.AuthType(); authType {
+	case EthPersonalSignAuth:
+		return EthSecp256k1Authenticator{}.Identifier(s.Identity())
+	case Ed25519Auth:
+		return Ed25519Authenticator{}.Identifier(s.Identity())
+	default:
+		return "", fmt.Errorf("unsupported signer type %q", authType)
+	}
+}
@@ -29,8 +29,10 @@ func Sha256Hex(data []byte) string {
 	return hex.EncodeToString(Sha256(data))
 }
 
+// Secp256k1PublicKeyFromBytes parses a secp256k1 public key in either the 33
+// byte compressed or 65 byte uncompressed form.
 func Secp256k1PublicKeyFromBytes(key []byte) (*Secp256k1PublicKey, error) {
-	pk, err := ethCrypto.UnmarshalPubkey(key)
+	pk, err := unmarshalSecp256k1PubKey(key)
 	if err != nil {
 		return nil, err
 	}
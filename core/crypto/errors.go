@@ -7,4 +7,5 @@ import (
 var (
 	ErrInvalidSignature       = errors.New("signature verification failed")
 	ErrInvalidSignatureLength = errors.New("invalid signature length")
+	ErrSignatureHighS         = errors.New("non-canonical signature with high S value")
 )
@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 )
@@ -61,6 +62,45 @@ func (pub *Secp256k1PublicKey) Bytes() []byte {
 	return ethCrypto.FromECDSAPub(pub.publicKey)
 }
 
+// CompressedBytes returns the 33 byte compressed form of the public key. Bytes
+// returns the 65 byte uncompressed form.
+func (pub *Secp256k1PublicKey) CompressedBytes() []byte {
+	return ethCrypto.CompressPubkey(pub.publicKey)
+}
+
+// CompressPubKey converts a secp256k1 public key in either the compressed or
+// uncompressed form to the 33 byte compressed form.
+func CompressPubKey(key []byte) ([]byte, error) {
+	pk, err := unmarshalSecp256k1PubKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return ethCrypto.CompressPubkey(pk), nil
+}
+
+// DecompressPubKey converts a secp256k1 public key in either the compressed or
+// uncompressed form to the 65 byte uncompressed form.
+func DecompressPubKey(key []byte) ([]byte, error) {
+	pk, err := unmarshalSecp256k1PubKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return ethCrypto.FromECDSAPub(pk), nil
+}
+
+// unmarshalSecp256k1PubKey parses a compressed or uncompressed public key,
+// checking that it is a point on the curve.
+func unmarshalSecp256k1PubKey(key []byte) (*ecdsa.PublicKey, error) {
+	switch len(key) {
+	case Secp256k1CompressedPublicKeySize:
+		return ethCrypto.DecompressPubkey(key)
+	case Secp256k1UncompressedPublicKeySize:
+		return ethCrypto.UnmarshalPubkey(key)
+	default:
+		return nil, fmt.Errorf("secp256k1: invalid public key length %d", len(key))
+	}
+}
+
 // Verify verifies the standard secp256k1 signature against the given hash.
 // Caller of this function should make sure the signature is in one of the following two formats:
 // - 65 bytes, [R || S || V] format. This is the standard format.
@@ -83,6 +123,10 @@ func (pub *Secp256k1PublicKey) Verify(sig []byte, hash []byte) error {
 		return fmt.Errorf("secp256k1: %w: expected: %d received: %d", ErrInvalidSignatureLength, secp256k1SignatureLength, len(sig))
 	}
 
+	if !Secp256k1SignatureIsLowS(sig) {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, ErrSignatureHighS)
+	}
+
 	if !ethCrypto.VerifySignature(pub.Bytes(), hash, sig) {
 		return ErrInvalidSignature
 	}
@@ -90,6 +134,23 @@ func (pub *Secp256k1PublicKey) Verify(sig []byte, hash []byte) error {
 	return nil
 }
 
+// secp256k1HalfN is half the order of the secp256k1 curve.
+var secp256k1HalfN = new(big.Int).Rsh(ethCrypto.S256().Params().N, 1)
+
+// Secp256k1SignatureIsLowS checks that the S value of a secp256k1 signature in
+// [R || S] or [R || S || V] format is in the lower half of the curve order.
+// For any valid signature (R, S), (R, N-S) is also valid for the same message
+// and key, so only the low-S form is accepted as canonical to prevent
+// signature malleability. Signatures from Sign and SignWithRecoveryID are
+// always low-S. This returns false if the signature is too short to contain S.
+func Secp256k1SignatureIsLowS(sig []byte) bool {
+	if len(sig) < secp256k1SignatureLength {
+		return false
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	return s.Cmp(secp256k1HalfN) <= 0
+}
+
 // GenerateSecp256k1Key generates a new secp256k1 private key.
 func GenerateSecp256k1Key() (*Secp256k1PrivateKey, error) {
 	key, err := ethCrypto.GenerateKey()
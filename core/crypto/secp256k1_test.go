@@ -3,9 +3,13 @@ package crypto_test
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/kwilteam/kwil-db/core/crypto"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -77,3 +81,75 @@ func TestSecp256k1PublicKey_Verify(t *testing.T) {
 		})
 	}
 }
+
+// toHighS converts a low-S [R || S || V] or [R || S] signature to the
+// equivalent high-S form (R, N-S), flipping the recovery ID if present.
+func toHighS(sig []byte) []byte {
+	n := ethCrypto.S256().Params().N
+	s := new(big.Int).SetBytes(sig[32:64])
+	highS := new(big.Int).Sub(n, s)
+
+	out := slices.Clone(sig)
+	highS.FillBytes(out[32:64])
+	if len(out) == 65 {
+		out[64] ^= 1
+	}
+	return out
+}
+
+func TestSecp256k1PublicKey_Verify_HighS(t *testing.T) {
+	pk, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte("foo"))
+	sig, err := pk.Sign(hash[:])
+	require.NoError(t, err)
+
+	require.True(t, crypto.Secp256k1SignatureIsLowS(sig))
+	require.NoError(t, pk.PubKey().Verify(sig, hash[:]))
+
+	highSig := toHighS(sig)
+	require.False(t, crypto.Secp256k1SignatureIsLowS(highSig))
+	err = pk.PubKey().Verify(highSig, hash[:])
+	require.ErrorIs(t, err, crypto.ErrSignatureHighS)
+	require.ErrorIs(t, err, crypto.ErrInvalidSignature)
+
+	require.False(t, crypto.Secp256k1SignatureIsLowS(sig[:40]))
+}
+
+func TestSecp256k1PublicKey_Compressed(t *testing.T) {
+	pk, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+
+	uncompressed := pk.PubKey().Bytes()
+	require.Len(t, uncompressed, crypto.Secp256k1UncompressedPublicKeySize)
+	compressed := pk.PubKey().CompressedBytes()
+	require.Len(t, compressed, crypto.Secp256k1CompressedPublicKeySize)
+
+	for _, key := range [][]byte{compressed, uncompressed} {
+		c, err := crypto.CompressPubKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, compressed, c)
+
+		u, err := crypto.DecompressPubKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, uncompressed, u)
+	}
+
+	hash := sha256.Sum256([]byte("foo"))
+	sig, err := pk.Sign(hash[:])
+	require.NoError(t, err)
+
+	for name, key := range map[string][]byte{"compressed": compressed, "uncompressed": uncompressed} {
+		t.Run(name, func(t *testing.T) {
+			pub, err := crypto.Secp256k1PublicKeyFromBytes(key)
+			require.NoError(t, err)
+			assert.NoError(t, pub.Verify(sig, hash[:]))
+		})
+	}
+
+	_, err = crypto.CompressPubKey(compressed[1:])
+	assert.Error(t, err)
+	_, err = crypto.DecompressPubKey(append([]byte{0x05}, compressed[1:]...)) // bad prefix
+	assert.Error(t, err)
+}
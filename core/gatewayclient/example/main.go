@@ -11,7 +11,6 @@ import (
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
 	"github.com/kwilteam/kwil-db/core/types/transactions"
-	"github.com/kwilteam/kwil-db/core/utils"
 	"log"
 	"net/http"
 	"slices"
@@ -103,7 +102,7 @@ func main() {
 	if err := json.Unmarshal(testKFJSON, &schema); err != nil {
 		log.Fatal(err)
 	}
-	dbid := utils.GenerateDBID(schema.Name, acctID)
+	dbid := types.GenerateDBID(schema.Name, acctID)
 
 	// See if it already deployed.
 	deployed := slices.ContainsFunc(datasets, func(d *types.DatasetIdentifier) bool {
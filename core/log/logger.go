@@ -101,6 +101,14 @@ func (l *Logger) IncreasedLevel(lvl Level) *Logger {
 	return l.WithOptions(zap.IncreaseLevel(zap.NewAtomicLevelAt(lvl)))
 }
 
+// IncreasedAtomicLevel is like IncreasedLevel, but the threshold may be changed
+// later with the AtomicLevel. It is ignored if it is lower than the parent
+// logger's level when this is called. After that, the higher of the two levels
+// applies.
+func (l *Logger) IncreasedAtomicLevel(lvl AtomicLevel) *Logger {
+	return l.WithOptions(zap.IncreaseLevel(lvl))
+}
+
 func (l *Logger) Sync() error {
 	return l.L.Sync()
 }
@@ -129,6 +137,9 @@ type Config struct {
 	// EncodeTime indicates how to encode the time. The default is
 	// TimeEncodingEpochFloat.
 	EncodeTime string
+	// AtomicLevel, if set, is used as the logger's level instead of Level,
+	// so that the level may be changed after the logger is created.
+	AtomicLevel *AtomicLevel
 }
 
 func rfc3339MilliTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
@@ -178,7 +189,9 @@ func NewChecked(config Config) (Logger, error) {
 		return Logger{}, fmt.Errorf("invalid log format %q", enc)
 	}
 
-	if config.Level != "" {
+	if config.AtomicLevel != nil {
+		cfg.Level = *config.AtomicLevel
+	} else if config.Level != "" {
 		cfg.Level = level
 	} else {
 		cfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
@@ -197,6 +210,14 @@ func NewChecked(config Config) (Logger, error) {
 
 type Level = zapcore.Level
 
+// AtomicLevel is a log level that may be changed while loggers are using it.
+type AtomicLevel = zap.AtomicLevel
+
+// NewAtomicLevelAt creates an AtomicLevel set to the given level.
+func NewAtomicLevelAt(lvl Level) AtomicLevel {
+	return zap.NewAtomicLevelAt(lvl)
+}
+
 const (
 	DebugLevel  Level = zap.DebugLevel
 	InfoLevel         = zap.InfoLevel
@@ -15,6 +15,9 @@ type AdminClient interface {
 	Leave(ctx context.Context) ([]byte, error)
 	ListValidators(ctx context.Context) ([]*types.Validator, error)
 	Peers(ctx context.Context) ([]*adminTypes.PeerInfo, error)
+	// PendingTxs lists up to limit transactions in the node's mempool. A
+	// limit of zero uses the node's default.
+	PendingTxs(ctx context.Context, limit int) ([]*adminTypes.PendingTx, error)
 	Remove(ctx context.Context, publicKey []byte) ([]byte, error)
 	Status(ctx context.Context) (*adminTypes.Status, error)
 	Version(ctx context.Context) (string, error)
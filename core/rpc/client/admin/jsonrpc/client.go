@@ -2,6 +2,8 @@ package adminclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"time"
 
@@ -77,6 +79,12 @@ func (cl *Client) JoinStatus(ctx context.Context, pubkey []byte) (*types.JoinReq
 	if err != nil {
 		return nil, err
 	}
+	if res.JoinRequest == nil {
+		return nil, errors.New("no join request in response")
+	}
+	if err = res.JoinRequest.Validate(); err != nil {
+		return nil, fmt.Errorf("malformed join request: %w", err)
+	}
 	return res.JoinRequest, nil
 }
 
@@ -114,6 +122,21 @@ func (cl *Client) Peers(ctx context.Context) ([]*adminTypes.PeerInfo, error) {
 	return res.Peers, err
 }
 
+// PendingTxs lists up to limit transactions in the node's mempool, which is
+// useful for diagnosing stuck transactions and nonce gaps. A limit of zero uses
+// the node's default.
+func (cl *Client) PendingTxs(ctx context.Context, limit int) ([]*adminTypes.PendingTx, error) {
+	cmd := &adminjson.PendingTxsRequest{
+		Limit: limit,
+	}
+	res := &adminjson.PendingTxsResponse{}
+	err := cl.CallMethod(ctx, string(adminjson.MethodPendingTxs), cmd, res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Txs, err
+}
+
 // Remove votes to remove the validator specified by the given public key.
 func (cl *Client) Remove(ctx context.Context, publicKey []byte) ([]byte, error) {
 	cmd := &adminjson.RemoveRequest{
@@ -164,7 +187,8 @@ func (cl *Client) Version(ctx context.Context) (string, error) {
 	return res.KwilVersion, err
 }
 
-// ListPendingJoins lists all active validator join requests.
+// ListPendingJoins lists all active validator join requests. Malformed join
+// requests in the response are skipped.
 func (cl *Client) ListPendingJoins(ctx context.Context) ([]*types.JoinRequest, error) {
 	cmd := &adminjson.ListJoinRequestsRequest{}
 	res := &adminjson.ListJoinRequestsResponse{}
@@ -172,7 +196,14 @@ func (cl *Client) ListPendingJoins(ctx context.Context) ([]*types.JoinRequest, e
 	if err != nil {
 		return nil, err
 	}
-	return res.JoinRequests, err
+	joins := make([]*types.JoinRequest, 0, len(res.JoinRequests))
+	for _, jr := range res.JoinRequests {
+		if jr == nil || jr.Validate() != nil {
+			continue
+		}
+		joins = append(joins, jr)
+	}
+	return joins, nil
 }
 
 // GetConfig gets the current config from the node.
@@ -0,0 +1,93 @@
+package adminclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	jsonrpc "github.com/kwilteam/kwil-db/core/rpc/json"
+	adminjson "github.com/kwilteam/kwil-db/core/rpc/json/admin"
+	"github.com/kwilteam/kwil-db/core/types"
+	adminTypes "github.com/kwilteam/kwil-db/core/types/admin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PendingTxs(t *testing.T) {
+	pending := []*adminTypes.PendingTx{
+		{Hash: []byte{0x01}, Size: 100, Sender: []byte("alice"), Nonce: 4},
+		{Hash: []byte{0x02}, Size: 120, Sender: []byte("alice"), Nonce: 6},
+		{Hash: []byte{0x03}, Size: 90}, // undecodable
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, string(adminjson.MethodPendingTxs), req.Method)
+
+		var cmd adminjson.PendingTxsRequest
+		require.NoError(t, json.Unmarshal(req.Params, &cmd))
+
+		txs := pending
+		if cmd.Limit > 0 && cmd.Limit < len(txs) {
+			txs = txs[:cmd.Limit]
+		}
+		resp, err := jsonrpc.NewResponse(req.ID, &adminjson.PendingTxsResponse{Txs: txs})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	cl := NewClient(u)
+
+	txs, err := cl.PendingTxs(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, pending, txs)
+
+	txs, err = cl.PendingTxs(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, pending[:2], txs)
+}
+
+func TestClient_ListPendingJoins(t *testing.T) {
+	valid := &types.JoinRequest{
+		Candidate: []byte("carol"),
+		Power:     10,
+		ExpiresAt: 100,
+		Board:     [][]byte{[]byte("alice"), []byte("bob")},
+		Approved:  []bool{true, false},
+	}
+	joins := []*types.JoinRequest{
+		valid,
+		{Candidate: []byte("dave"), Power: 10, ExpiresAt: 100, Board: [][]byte{[]byte("alice")}}, // missing approval
+		{Power: 10, ExpiresAt: 100}, // missing candidate
+		nil,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, string(adminjson.MethodValListJoins), req.Method)
+
+		resp, err := jsonrpc.NewResponse(req.ID, &adminjson.ListJoinRequestsResponse{JoinRequests: joins})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	cl := NewClient(u)
+
+	pending, err := cl.ListPendingJoins(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*types.JoinRequest{valid}, pending)
+}
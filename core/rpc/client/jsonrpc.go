@@ -63,6 +63,9 @@ func NewJSONRPCClient(url *url.URL, opts ...RPCClientOpts) *JSONRPCClient {
 	for _, opt := range opts {
 		opt(clientOpts)
 	}
+	if clientOpts.maxConns > 0 {
+		clientOpts.client = withMaxConns(clientOpts.client, clientOpts.maxConns)
+	}
 
 	cl := &JSONRPCClient{
 		endpoint: url.String(),
@@ -76,8 +79,9 @@ func NewJSONRPCClient(url *url.URL, opts ...RPCClientOpts) *JSONRPCClient {
 type RPCClientOpts func(*clientOptions)
 
 type clientOptions struct {
-	client *http.Client
-	log    log.Logger
+	client   *http.Client
+	log      log.Logger
+	maxConns int
 }
 
 func WithLogger(log log.Logger) RPCClientOpts {
@@ -92,6 +96,45 @@ func WithHTTPClient(client *http.Client) RPCClientOpts {
 	}
 }
 
+// WithMaxConns sets the maximum number of connections to the server, all of
+// which are kept open for reuse when idle. Concurrent requests are spread over
+// the connections, and wait for one to be available when all n are busy.
+// Connections that are closed by the server or are idle for too long are
+// dropped. Without this option, there is no limit, but only two connections
+// are kept open when idle, so bursts of concurrent requests keep reconnecting.
+//
+// This applies to the http.Client given with WithHTTPClient, if any, unless
+// it has a custom Transport that is not an *http.Transport. That client is not
+// modified.
+func WithMaxConns(n int) RPCClientOpts {
+	return func(c *clientOptions) {
+		c.maxConns = n
+	}
+}
+
+// withMaxConns returns a copy of the http.Client with its Transport limited to
+// n connections per host, all of which may be idle.
+func withMaxConns(client *http.Client, n int) *http.Client {
+	var transport *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return client
+	}
+	transport.MaxConnsPerHost = n
+	transport.MaxIdleConnsPerHost = n
+	if transport.MaxIdleConns != 0 && transport.MaxIdleConns < n {
+		transport.MaxIdleConns = n
+	}
+
+	clientCopy := *client
+	clientCopy.Transport = transport
+	return &clientCopy
+}
+
 func (cl *JSONRPCClient) nextReqID() string {
 	id := cl.reqID.Add(1)
 	return strconv.FormatUint(id, 10)
@@ -118,17 +161,50 @@ func (cl *JSONRPCClient) CallMethod(ctx context.Context, method string, cmd, res
 	id := cl.nextReqID()
 	req := jsonrpc.NewRequest(id, method, params)
 
-	request, err := json.Marshal(req)
+	resp := &jsonrpc.Response{}
+	httpErr, err := cl.post(ctx, req, resp)
 	if err != nil {
 		return err
 	}
 
+	if resp.Error != nil {
+		return clientError(resp.Error)
+	} // any not OK http status code should have
+
+	if resp.JSONRPC != "2.0" { // indicates response body was not a jsonrpc.Response but didn't fail Decode
+		if httpErr != nil {
+			return httpErr
+		}
+		return fmt.Errorf("invalid JSON-RPC response")
+	}
+
+	// if resp.ID != id {
+	// 	fmt.Printf("got id %v, expected %v\n", resp.ID, id)
+	// } // who cares, this is http post
+
+	if err = json.Unmarshal(resp.Result, res); err != nil {
+		return fmt.Errorf("failed to decode result as response: %w", errors.Join(err, httpErr))
+	}
+
+	return nil
+}
+
+// post marshals the request object, which may be a single jsonrpc.Request or a
+// batch of them, sends it to the server, and decodes the response body into
+// resp. The returned httpErr reflects any unsuccessful http status code, and is
+// useful when a decoded response does not otherwise indicate an error.
+func (cl *JSONRPCClient) post(ctx context.Context, req, resp any) (httpErr, err error) {
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build and perform the http request.
 	requestReader := bytes.NewReader(request)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		cl.endpoint, requestReader)
 	if err != nil {
-		return fmt.Errorf("failed to construct new http request: %w", err)
+		return nil, fmt.Errorf("failed to construct new http request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -136,14 +212,13 @@ func (cl *JSONRPCClient) CallMethod(ctx context.Context, method string, cmd, res
 
 	httpResponse, err := cl.conn.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("http post failed: %w", err)
+		return nil, fmt.Errorf("http post failed: %w", err)
 	}
 	defer httpResponse.Body.Close()
 
 	// For the most part we ignore the http status code in favor of structured
 	// errors in the response, but in case we cannot decode any response body,
 	// get an error based on the http status code.
-	var httpErr error
 	switch status := httpResponse.StatusCode; status {
 	case http.StatusOK: // expected with nil resp.Error
 	case http.StatusUnauthorized:
@@ -158,35 +233,104 @@ func (cl *JSONRPCClient) CallMethod(ctx context.Context, method string, cmd, res
 		}
 	}
 
-	resp := &jsonrpc.Response{}
 	err = json.NewDecoder(httpResponse.Body).Decode(resp)
 	if err != nil {
 		if httpErr != nil {
-			return httpErr
+			return nil, httpErr
 		}
-		return fmt.Errorf("failed to decode response: %w", errors.Join(err, httpErr))
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if resp.Error != nil {
-		return clientError(resp.Error)
-	} // any not OK http status code should have
+	return httpErr, nil
+}
 
-	if resp.JSONRPC != "2.0" { // indicates response body was not a jsonrpc.Response but didn't fail Decode
-		if httpErr != nil {
-			return httpErr
+// BatchCall is a single method call in a batch request. See Batch.
+type BatchCall struct {
+	// Method is the name of the JSON-RPC method to call.
+	Method string
+	// Params is the method's request parameter, marshalled into the "params"
+	// field of the request.
+	Params any
+	// Result is a pointer to the method's response object, into which the
+	// call's result is unmarshalled if it succeeds.
+	Result any
+}
+
+// BatchResult is the outcome of a single call in a batch request. The result
+// itself is unmarshalled into the corresponding BatchCall's Result.
+type BatchResult struct {
+	// Err is the error for this call, if any. Like the errors returned by
+	// CallMethod, it may be matched against ErrNotFound etc. and a *RPCError.
+	Err error
+}
+
+// Batch makes a single JSON-RPC batch request with all of the provided calls.
+// Responses are matched to calls by request ID, and the returned BatchResults
+// are in the same order as the calls. The returned error is only non-nil if the
+// batch as a whole failed, such as if the server could not be reached or did
+// not accept the batch. Errors for individual calls are in the BatchResults.
+func (cl *JSONRPCClient) Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	reqs := make([]*jsonrpc.Request, len(calls))
+	callIdx := make(map[string]int, len(calls))
+	for i, call := range calls {
+		// Result needs to be a pointer otherwise we can't unmarshal into it.
+		if rtp := reflect.TypeOf(call.Result); rtp == nil || rtp.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("result for call %d (%s) must be a pointer", i, call.Method)
 		}
-		return fmt.Errorf("invalid JSON-RPC response")
+
+		params, err := json.Marshal(call.Params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params for call %d (%s): %w", i, call.Method, err)
+		}
+
+		id := cl.nextReqID()
+		reqs[i] = jsonrpc.NewRequest(id, call.Method, params)
+		callIdx[id] = i
 	}
 
-	// if resp.ID != id {
-	// 	fmt.Printf("got id %v, expected %v\n", resp.ID, id)
-	// } // who cares, this is http post
+	var resps []*jsonrpc.Response
+	httpErr, err := cl.post(ctx, reqs, &resps)
+	if err != nil {
+		return nil, err
+	}
 
-	if err = json.Unmarshal(resp.Result, res); err != nil {
-		return fmt.Errorf("failed to decode result as response: %w", errors.Join(err, httpErr))
+	results := make([]BatchResult, len(calls))
+	answered := make([]bool, len(calls))
+	for _, resp := range resps {
+		if resp == nil {
+			continue
+		}
+		id, _ := resp.ID.(string) // we always send string IDs
+		i, ok := callIdx[id]
+		if !ok || answered[i] {
+			cl.log.Warnf("unexpected response ID %v in batch response", resp.ID)
+			continue
+		}
+		answered[i] = true
+
+		switch {
+		case resp.Error != nil:
+			results[i].Err = clientError(resp.Error)
+		case resp.JSONRPC != "2.0":
+			results[i].Err = errors.Join(errors.New("invalid JSON-RPC response"), httpErr)
+		default:
+			if err = json.Unmarshal(resp.Result, calls[i].Result); err != nil {
+				results[i].Err = fmt.Errorf("failed to decode result as response: %w", err)
+			}
+		}
 	}
 
-	return nil
+	for i := range results {
+		if !answered[i] {
+			results[i].Err = errors.Join(errors.New("no response for request"), httpErr)
+		}
+	}
+
+	return results, nil
 }
 
 // clientError joins a jsonrpc.Error with a client.RPCError and any appropriate
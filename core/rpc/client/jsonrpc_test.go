@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	jsonrpc "github.com/kwilteam/kwil-db/core/rpc/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type echoParams struct {
+	Message string `json:"message"`
+}
+
+func TestJSONRPCClient_Batch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []*jsonrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Errorf("server failed to decode batch: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resps := make([]*jsonrpc.Response, 0, len(reqs))
+		for _, req := range reqs {
+			if req.Method != "echo" {
+				resps = append(resps, jsonrpc.NewErrorResponse(req.ID,
+					jsonrpc.NewError(jsonrpc.ErrorUnknownMethod, "unknown method", nil)))
+				continue
+			}
+			var params echoParams
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				t.Errorf("server failed to decode params: %v", err)
+			}
+			resp, err := jsonrpc.NewResponse(req.ID, params)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+		slices.Reverse(resps) // responses may be in any order
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resps); err != nil {
+			t.Errorf("server failed to encode responses: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	cl := NewJSONRPCClient(u)
+
+	var res0, res1, res2 echoParams
+	results, err := cl.Batch(context.Background(), []BatchCall{
+		{Method: "echo", Params: &echoParams{"a"}, Result: &res0},
+		{Method: "nope", Params: &echoParams{"b"}, Result: &res1},
+		{Method: "echo", Params: &echoParams{"c"}, Result: &res2},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "a", res0.Message)
+
+	require.Error(t, results[1].Err)
+	assert.ErrorIs(t, results[1].Err, ErrNotFound)
+	var rpcErr *RPCError
+	require.True(t, errors.As(results[1].Err, &rpcErr))
+	assert.Equal(t, int32(jsonrpc.ErrorUnknownMethod), rpcErr.Code)
+	assert.Empty(t, res1.Message)
+
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, "c", res2.Message)
+
+	// Results must be pointers.
+	_, err = cl.Batch(context.Background(), []BatchCall{{Method: "echo", Result: res0}})
+	require.Error(t, err)
+}
+
+func TestJSONRPCClient_WithMaxConns(t *testing.T) {
+	const maxConns, calls = 4, 12
+
+	var mtx sync.Mutex
+	var inFlight, maxInFlight, newConns int
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("server failed to decode request: %v", err)
+		}
+
+		mtx.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mtx.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mtx.Lock()
+		inFlight--
+		mtx.Unlock()
+
+		resp, err := jsonrpc.NewResponse(req.ID, echoParams{Message: "hi"})
+		if err != nil {
+			t.Errorf("server failed to make response: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mtx.Lock()
+			newConns++
+			mtx.Unlock()
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	cl := NewJSONRPCClient(u, WithMaxConns(maxConns))
+
+	// Two rounds, where the second should reuse the connections of the first.
+	for round := 0; round < 2; round++ {
+		var wg sync.WaitGroup
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var res echoParams
+				assert.NoError(t, cl.CallMethod(context.Background(), "echo", &echoParams{}, &res))
+			}()
+		}
+		wg.Wait()
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Greater(t, maxInFlight, 1, "requests were serialized")
+	assert.LessOrEqual(t, maxInFlight, maxConns)
+	assert.LessOrEqual(t, newConns, maxConns)
+}
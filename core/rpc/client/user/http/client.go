@@ -55,6 +55,11 @@ func NewClient(target *url.URL, opts ...ClientOption) *Client {
 var _ user.TxSvcClient = (*Client)(nil)
 
 func (c *Client) Broadcast(ctx context.Context, tx *transactions.Transaction, sync client.BroadcastWait) ([]byte, error) {
+	if tx.Body != nil && tx.Body.ValidUntilHeight != 0 {
+		// The HTTP gateway's transaction body has no such field, so the
+		// transaction would arrive with an invalid signature.
+		return nil, errors.New("transactions with a valid until height cannot be broadcast via the HTTP gateway")
+	}
 	var bcastSync httpTx.TxBroadcastSync // swagger uses a string for this enum unlike grpc
 	switch sync {
 	case client.BroadcastWaitAsync:
@@ -288,3 +293,9 @@ func (c *Client) TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTx
 		TxResult: *convertedTxResult,
 	}, nil
 }
+
+// Validators is not supported by the HTTP gateway, which has no validators
+// endpoint.
+func (c *Client) Validators(ctx context.Context) ([]*types.Validator, error) {
+	return nil, errors.New("validators are not available from the HTTP gateway")
+}
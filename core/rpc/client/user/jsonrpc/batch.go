@@ -0,0 +1,60 @@
+package jsonrpc
+
+import (
+	rpcclient "github.com/kwilteam/kwil-db/core/rpc/client"
+	userjson "github.com/kwilteam/kwil-db/core/rpc/json/user"
+	"github.com/kwilteam/kwil-db/core/types"
+)
+
+// The following functions build common calls for use with the Batch method of
+// the Client. The result of each call is unmarshalled into the provided
+// response object if the call succeeds, which should be checked with the Err
+// field of the corresponding BatchResult.
+
+// PingCall creates a batch call for MethodPing.
+func PingCall(res *userjson.PingResponse) rpcclient.BatchCall {
+	return rpcclient.BatchCall{
+		Method: string(userjson.MethodPing),
+		Params: &userjson.PingRequest{Message: "ping"},
+		Result: res,
+	}
+}
+
+// ChainInfoCall creates a batch call for MethodChainInfo.
+func ChainInfoCall(res *types.ChainInfo) rpcclient.BatchCall {
+	return rpcclient.BatchCall{
+		Method: string(userjson.MethodChainInfo),
+		Params: &userjson.ChainInfoRequest{},
+		Result: res,
+	}
+}
+
+// AccountCall creates a batch call for MethodAccount.
+func AccountCall(pubKey []byte, status types.AccountStatus, res *userjson.AccountResponse) rpcclient.BatchCall {
+	return rpcclient.BatchCall{
+		Method: string(userjson.MethodAccount),
+		Params: &userjson.AccountRequest{
+			Identifier: pubKey,
+			Status:     &status,
+		},
+		Result: res,
+	}
+}
+
+// SchemaCall creates a batch call for MethodSchema.
+func SchemaCall(dbid string, res *userjson.SchemaResponse) rpcclient.BatchCall {
+	return rpcclient.BatchCall{
+		Method: string(userjson.MethodSchema),
+		Params: &userjson.SchemaRequest{DBID: dbid},
+		Result: res,
+	}
+}
+
+// ListDatabasesCall creates a batch call for MethodDatabases.
+func ListDatabasesCall(owner []byte, res *userjson.ListDatabasesResponse) rpcclient.BatchCall {
+	return rpcclient.BatchCall{
+		Method: string(userjson.MethodDatabases),
+		Params: &userjson.ListDatabasesRequest{Owner: owner},
+		Result: res,
+	}
+}
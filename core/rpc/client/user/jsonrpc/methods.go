@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"strings"
 
 	rpcclient "github.com/kwilteam/kwil-db/core/rpc/client"
 	"github.com/kwilteam/kwil-db/core/rpc/client/user"
@@ -115,6 +116,69 @@ func (cl *Client) EstimateCost(ctx context.Context, tx *transactions.Transaction
 		return nil, err
 	}
 
+	return parsePrice(res)
+}
+
+// maxBatchSize is the largest number of requests that a kwild node accepts in
+// one JSON-RPC batch request.
+const maxBatchSize = 100
+
+// EstimateCostBatch estimates the cost of each of the transactions, returning
+// the prices in the same order. The estimates are requested in JSON-RPC batch
+// requests of up to maxBatchSize transactions. If the server does not support
+// batch requests, the prices are requested one at a time with EstimateCost. An
+// error is returned if the cost of any transaction cannot be estimated.
+func (cl *Client) EstimateCostBatch(ctx context.Context, txs []*transactions.Transaction) ([]*big.Int, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	calls := make([]rpcclient.BatchCall, len(txs))
+	resps := make([]userjson.EstimatePriceResponse, len(txs))
+	for i, tx := range txs {
+		calls[i] = rpcclient.BatchCall{
+			Method: string(userjson.MethodPrice),
+			Params: &userjson.EstimatePriceRequest{Tx: tx},
+			Result: &resps[i],
+		}
+	}
+
+	prices := make([]*big.Int, len(txs))
+	for start := 0; start < len(txs); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(txs))
+		results, err := cl.Batch(ctx, calls[start:end])
+		if err != nil {
+			// If an earlier batch succeeded, the server supports batches.
+			if ctx.Err() != nil || start > 0 {
+				return nil, err
+			}
+			// Assume the server does not support batch requests. If it is
+			// some other problem, the individual requests will fail too.
+			for i, tx := range txs {
+				prices[i], err = cl.EstimateCost(ctx, tx)
+				if err != nil {
+					return nil, fmt.Errorf("transaction %d: %w", i, err)
+				}
+			}
+			return prices, nil
+		}
+
+		for j, res := range results {
+			i := start + j
+			if res.Err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, res.Err)
+			}
+			prices[i], err = parsePrice(&resps[i])
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, err)
+			}
+		}
+	}
+
+	return prices, nil
+}
+
+func parsePrice(res *userjson.EstimatePriceResponse) (*big.Int, error) {
 	// parse result.Price to big.Int
 	price, ok := new(big.Int).SetString(res.Price, 10)
 	if !ok {
@@ -195,6 +259,72 @@ func (cl *Client) Query(ctx context.Context, dbid, query string) ([]map[string]a
 	return jsonUtil.UnmarshalMapWithoutFloat(res.Result)
 }
 
+// QueryWithParams performs an ad hoc SQL query with named parameters, such as
+// "SELECT * FROM users WHERE id = $id". The parameter values are sent
+// separately from the query and bound by the server, so they are never
+// interpolated into the SQL. The names in params may omit the $ prefix.
+func (cl *Client) QueryWithParams(ctx context.Context, dbid, query string, params map[string]any) ([]map[string]any, error) {
+	encParams := make(map[string]*transactions.EncodedValue, len(params))
+	for name, val := range params {
+		encVal, err := transactions.EncodeValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode query parameter %s: %w", name, err)
+		}
+		if !strings.HasPrefix(name, "$") {
+			name = "$" + name
+		}
+		encParams[name] = encVal
+	}
+
+	cmd := &userjson.QueryRequest{
+		DBID:   dbid,
+		Query:  query,
+		Params: encParams,
+	}
+	res := &userjson.QueryResponse{}
+	err := cl.CallMethod(ctx, string(userjson.MethodQuery), cmd, res)
+	if err != nil {
+		return nil, err
+	}
+	return jsonUtil.UnmarshalMapWithoutFloat(res.Result)
+}
+
+// QueryPaged performs an ad hoc SQL query, requesting the results in pages of
+// up to pageSize rows. The fn callback is called with each page in order, until
+// the results are exhausted or fn returns an error, which is returned. The
+// server executes the query for each page without holding results between
+// requests, so the pages are only consistent if the data is not modified
+// while they are retrieved.
+func (cl *Client) QueryPaged(ctx context.Context, dbid, query string, pageSize int64, fn func(page []map[string]any) error) error {
+	if pageSize <= 0 {
+		return errors.New("page size must be positive")
+	}
+
+	cmd := &userjson.QueryRequest{
+		DBID:     dbid,
+		Query:    query,
+		PageSize: pageSize,
+	}
+	for {
+		res := &userjson.QueryResponse{}
+		err := cl.CallMethod(ctx, string(userjson.MethodQuery), cmd, res)
+		if err != nil {
+			return err
+		}
+		page, err := jsonUtil.UnmarshalMapWithoutFloat(res.Result)
+		if err != nil {
+			return err
+		}
+		if err = fn(page); err != nil {
+			return err
+		}
+		if res.NextCursor == "" {
+			return nil
+		}
+		cmd.Cursor = res.NextCursor
+	}
+}
+
 func (cl *Client) TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTxQueryResponse, error) {
 	cmd := &userjson.TxQueryRequest{
 		TxHash: txHash,
@@ -212,3 +342,14 @@ func (cl *Client) TxQuery(ctx context.Context, txHash []byte) (*transactions.TcT
 		TxResult: *res.TxResult,
 	}, nil
 }
+
+// Validators gets the current validator set.
+func (cl *Client) Validators(ctx context.Context) ([]*types.Validator, error) {
+	cmd := &userjson.ValidatorsRequest{}
+	res := &userjson.ValidatorsResponse{}
+	err := cl.CallMethod(ctx, string(userjson.MethodValidators), cmd, res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Validators, nil
+}
@@ -0,0 +1,202 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	jsonrpc "github.com/kwilteam/kwil-db/core/rpc/json"
+	userjson "github.com/kwilteam/kwil-db/core/rpc/json/user"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_QueryWithParams(t *testing.T) {
+	var got map[string]any
+	cl := newFakeServer(t, map[jsonrpc.Method]fakeHandler{
+		userjson.MethodQuery: func(params json.RawMessage) (any, *jsonrpc.Error) {
+			var req userjson.QueryRequest
+			if err := json.Unmarshal(params, &req); err != nil {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, err.Error(), nil)
+			}
+			assert.Equal(t, "xdbid", req.DBID)
+			assert.Equal(t, "SELECT * FROM users WHERE id = $id AND name = $name", req.Query)
+
+			got = make(map[string]any, len(req.Params))
+			for name, val := range req.Params {
+				dec, err := val.Decode()
+				if err != nil {
+					return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, err.Error(), nil)
+				}
+				got[name] = dec
+			}
+
+			return &userjson.QueryResponse{Result: []byte(`[{"id":42,"name":"alice"}]`)}, nil
+		},
+	})
+
+	res, err := cl.QueryWithParams(context.Background(), "xdbid",
+		"SELECT * FROM users WHERE id = $id AND name = $name",
+		map[string]any{"id": 42, "$name": "alice"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "alice", res[0]["name"])
+
+	// The server receives typed values with $-prefixed names.
+	assert.Equal(t, map[string]any{
+		"$id":   int64(42),
+		"$name": "alice",
+	}, got)
+}
+
+func TestClient_EstimateCostBatch(t *testing.T) {
+	// The price of each transaction is 100 times its nonce.
+	priceResponse := func(req *jsonrpc.Request) *jsonrpc.Response {
+		if req.Method != string(userjson.MethodPrice) {
+			return jsonrpc.NewErrorResponse(req.ID, jsonrpc.NewError(jsonrpc.ErrorUnknownMethod, "unknown method", nil))
+		}
+		var priceReq userjson.EstimatePriceRequest
+		if err := json.Unmarshal(req.Params, &priceReq); err != nil {
+			return jsonrpc.NewErrorResponse(req.ID, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, err.Error(), nil))
+		}
+		price := strconv.FormatUint(100*priceReq.Tx.Body.Nonce, 10)
+		resp, err := jsonrpc.NewResponse(req.ID, &userjson.EstimatePriceResponse{Price: price})
+		require.NoError(t, err)
+		return resp
+	}
+
+	newServer := func(t *testing.T, supportBatch bool) (*Client, *atomic.Int64) {
+		var numRequests atomic.Int64
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			numRequests.Add(1)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			var resp any
+			if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+				if !supportBatch {
+					http.Error(w, "batch requests not supported", http.StatusBadRequest)
+					return
+				}
+				var reqs []*jsonrpc.Request
+				require.NoError(t, json.Unmarshal(body, &reqs))
+				if len(reqs) > 100 { // as kwild does
+					http.Error(w, "batch too large", http.StatusBadRequest)
+					return
+				}
+				var resps []*jsonrpc.Response
+				for _, req := range reqs {
+					resps = append(resps, priceResponse(req))
+				}
+				resp = resps
+			} else {
+				var req jsonrpc.Request
+				require.NoError(t, json.Unmarshal(body, &req))
+				resp = priceResponse(&req)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+		t.Cleanup(srv.Close)
+
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+		return NewClient(u), &numRequests
+	}
+
+	makeTxs := func(t *testing.T, n int) ([]*transactions.Transaction, []*big.Int) {
+		txs := make([]*transactions.Transaction, n)
+		prices := make([]*big.Int, n)
+		for i := range txs {
+			tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chain", uint64(i+1))
+			require.NoError(t, err)
+			txs[i] = tx
+			prices[i] = big.NewInt(100 * int64(i+1))
+		}
+		return txs, prices
+	}
+	txs, want := makeTxs(t, 2)
+
+	t.Run("batch", func(t *testing.T) {
+		cl, numRequests := newServer(t, true)
+		prices, err := cl.EstimateCostBatch(context.Background(), txs)
+		require.NoError(t, err)
+		assert.Equal(t, want, prices)
+		assert.Equal(t, int64(1), numRequests.Load())
+	})
+
+	t.Run("no batch support", func(t *testing.T) {
+		cl, numRequests := newServer(t, false)
+		prices, err := cl.EstimateCostBatch(context.Background(), txs)
+		require.NoError(t, err)
+		assert.Equal(t, want, prices)
+		assert.Equal(t, int64(1+len(txs)), numRequests.Load())
+	})
+
+	t.Run("multiple batches", func(t *testing.T) {
+		txs, want := makeTxs(t, 250)
+		cl, numRequests := newServer(t, true)
+		prices, err := cl.EstimateCostBatch(context.Background(), txs)
+		require.NoError(t, err)
+		assert.Equal(t, want, prices)
+		assert.Equal(t, int64(3), numRequests.Load())
+	})
+}
+
+func TestClient_QueryPaged(t *testing.T) {
+	cl := newFakeServer(t, map[jsonrpc.Method]fakeHandler{
+		userjson.MethodQuery: func(params json.RawMessage) (any, *jsonrpc.Error) {
+			var req userjson.QueryRequest
+			if err := json.Unmarshal(params, &req); err != nil {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, err.Error(), nil)
+			}
+			assert.Equal(t, int64(2), req.PageSize)
+
+			switch req.Cursor {
+			case "":
+				return &userjson.QueryResponse{
+					Result:     []byte(`[{"id":1},{"id":2}]`),
+					NextCursor: "page2",
+				}, nil
+			case "page2":
+				return &userjson.QueryResponse{Result: []byte(`[{"id":3}]`)}, nil
+			default:
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "invalid cursor", nil)
+			}
+		},
+	})
+
+	var pages [][]map[string]any
+	err := cl.QueryPaged(context.Background(), "xdbid", "SELECT id FROM users", 2,
+		func(page []map[string]any) error {
+			pages = append(pages, page)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	assert.Equal(t, []map[string]any{{"id": int64(1)}, {"id": int64(2)}}, pages[0])
+	assert.Equal(t, []map[string]any{{"id": int64(3)}}, pages[1])
+
+	// An error from the callback stops paging.
+	errStop := errors.New("stop")
+	var calls int
+	err = cl.QueryPaged(context.Background(), "xdbid", "SELECT id FROM users", 2,
+		func(page []map[string]any) error {
+			calls++
+			return errStop
+		})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
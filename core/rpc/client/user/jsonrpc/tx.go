@@ -0,0 +1,63 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	rpcclient "github.com/kwilteam/kwil-db/core/rpc/client"
+	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+)
+
+// DeploySchema builds a transaction with the Schema payload, signs it with the
+// given signer, and broadcasts it. The nonce and fee are set in the same way as
+// core/client.Client, using the signer's pending account nonce and the node's
+// price estimate. It returns the transaction hash.
+func (cl *Client) DeploySchema(ctx context.Context, schema *transactions.Schema, signer auth.Signer, sync rpcclient.BroadcastWait) ([]byte, error) {
+	return cl.signAndBroadcast(ctx, schema, signer, sync)
+}
+
+// DropSchema builds, signs, and broadcasts a transaction to drop the dataset
+// with the given DBID. It returns the transaction hash.
+func (cl *Client) DropSchema(ctx context.Context, dbid string, signer auth.Signer, sync rpcclient.BroadcastWait) ([]byte, error) {
+	if dbid == "" {
+		return nil, errors.New("dbid must not be empty")
+	}
+	return cl.signAndBroadcast(ctx, &transactions.DropSchema{DBID: dbid}, signer, sync)
+}
+
+// signAndBroadcast creates a transaction for the payload using the chain ID
+// reported by the node, signs it, and broadcasts it.
+func (cl *Client) signAndBroadcast(ctx context.Context, payload transactions.Payload, signer auth.Signer, sync rpcclient.BroadcastWait) ([]byte, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer must be set to create a transaction")
+	}
+
+	chainInfo, err := cl.ChainInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("chain_info: %w", err)
+	}
+
+	acct, err := cl.GetAccount(ctx, signer.Identity(), types.AccountStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := transactions.CreateTransaction(payload, chainInfo.ChainID, uint64(acct.Nonce+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	tx.Body.Fee, err = cl.EstimateCost(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate price: %w", err)
+	}
+
+	if err = tx.Sign(signer); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return cl.Broadcast(ctx, tx, sync)
+}
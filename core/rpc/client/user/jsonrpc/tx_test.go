@@ -0,0 +1,150 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	rpcclient "github.com/kwilteam/kwil-db/core/rpc/client"
+	jsonrpc "github.com/kwilteam/kwil-db/core/rpc/json"
+	userjson "github.com/kwilteam/kwil-db/core/rpc/json/user"
+	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHandler handles a JSON-RPC request's params, returning either a result to
+// be marshalled, or an error.
+type fakeHandler func(params json.RawMessage) (any, *jsonrpc.Error)
+
+// newFakeServer starts an HTTP server that dispatches JSON-RPC requests to the
+// handlers by method name, and returns a Client for it.
+func newFakeServer(t *testing.T, handlers map[jsonrpc.Method]fakeHandler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var resp *jsonrpc.Response
+		handler, ok := handlers[jsonrpc.Method(req.Method)]
+		if !ok {
+			resp = jsonrpc.NewErrorResponse(req.ID, jsonrpc.NewError(jsonrpc.ErrorUnknownMethod, "unknown method", nil))
+		} else if result, rpcErr := handler(req.Params); rpcErr != nil {
+			resp = jsonrpc.NewErrorResponse(req.ID, rpcErr)
+		} else {
+			var err error
+			resp, err = jsonrpc.NewResponse(req.ID, result)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return NewClient(u)
+}
+
+// txHandlers returns the handlers needed to build and broadcast a transaction.
+// Broadcast transactions are sent on the returned channel.
+func txHandlers(t *testing.T, chainID string, nonce int64) (map[jsonrpc.Method]fakeHandler, <-chan *transactions.Transaction) {
+	txs := make(chan *transactions.Transaction, 1)
+	return map[jsonrpc.Method]fakeHandler{
+		userjson.MethodChainInfo: func(json.RawMessage) (any, *jsonrpc.Error) {
+			return &types.ChainInfo{ChainID: chainID}, nil
+		},
+		userjson.MethodAccount: func(json.RawMessage) (any, *jsonrpc.Error) {
+			return &userjson.AccountResponse{Balance: "0", Nonce: nonce}, nil
+		},
+		userjson.MethodPrice: func(json.RawMessage) (any, *jsonrpc.Error) {
+			return &userjson.EstimatePriceResponse{Price: "42"}, nil
+		},
+		userjson.MethodBroadcast: func(params json.RawMessage) (any, *jsonrpc.Error) {
+			var req userjson.BroadcastRequest
+			require.NoError(t, json.Unmarshal(params, &req))
+			txs <- req.Tx
+			return &userjson.BroadcastResponse{TxHash: []byte{1, 2, 3}}, nil
+		},
+	}, txs
+}
+
+func newTestSigner(t *testing.T) auth.Signer {
+	pk, err := crypto.GenerateSecp256k1Key()
+	require.NoError(t, err)
+	return &auth.EthPersonalSigner{Key: *pk}
+}
+
+func TestClient_DeploySchema(t *testing.T) {
+	handlers, txs := txHandlers(t, "test-chain", 4)
+	cl := newFakeServer(t, handlers)
+	signer := newTestSigner(t)
+
+	schema := &transactions.Schema{
+		Name: "test_db",
+		Tables: []*transactions.Table{{
+			Name: "users",
+			Columns: []*transactions.Column{{
+				Name: "id",
+				Type: &transactions.DataType{Name: "int"},
+				Attributes: []*transactions.Attribute{{
+					Type: "primary_key",
+				}},
+			}},
+		}},
+	}
+
+	ctx := context.Background()
+	txHash, err := cl.DeploySchema(ctx, schema, signer, rpcclient.BroadcastWaitSync)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, txHash)
+
+	tx := <-txs
+	require.Equal(t, transactions.PayloadTypeDeploySchema, tx.Body.PayloadType)
+	require.Equal(t, "test-chain", tx.Body.ChainID)
+	require.Equal(t, uint64(5), tx.Body.Nonce)
+	require.Equal(t, int64(42), tx.Body.Fee.Int64())
+	require.NoError(t, tx.Verify())
+
+	var gotSchema transactions.Schema
+	require.NoError(t, gotSchema.UnmarshalBinary(tx.Body.Payload))
+	require.Equal(t, schema.Name, gotSchema.Name)
+	require.Len(t, gotSchema.Tables, 1)
+}
+
+func TestClient_DropSchema(t *testing.T) {
+	handlers, txs := txHandlers(t, "test-chain", 0)
+	cl := newFakeServer(t, handlers)
+	signer := newTestSigner(t)
+
+	ctx := context.Background()
+	_, err := cl.DropSchema(ctx, "", signer, rpcclient.BroadcastWaitSync)
+	require.Error(t, err)
+
+	txHash, err := cl.DropSchema(ctx, "xdbid", signer, rpcclient.BroadcastWaitCommit)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, txHash)
+
+	tx := <-txs
+	require.Equal(t, transactions.PayloadTypeDropSchema, tx.Body.PayloadType)
+	require.Equal(t, uint64(1), tx.Body.Nonce)
+	require.NoError(t, tx.Verify())
+
+	payload, err := transactions.UnmarshalPayload(tx.Body.PayloadType, tx.Body.Payload)
+	require.NoError(t, err)
+	require.Equal(t, &transactions.DropSchema{DBID: "xdbid"}, payload)
+}
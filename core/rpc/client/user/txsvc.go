@@ -24,4 +24,5 @@ type TxSvcClient interface {
 	Ping(ctx context.Context) (string, error)
 	Query(ctx context.Context, dbid string, query string) ([]map[string]any, error)
 	TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTxQueryResponse, error)
+	Validators(ctx context.Context) ([]*types.Validator, error)
 }
@@ -4,6 +4,9 @@ package adminjson
 
 type StatusRequest struct{}
 type PeersRequest struct{}
+type PendingTxsRequest struct {
+	Limit int `json:"limit,omitempty"`
+}
 type GetConfigRequest struct{}
 type ApproveRequest struct {
 	PubKey []byte `json:"pubkey"`
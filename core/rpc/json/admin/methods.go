@@ -16,4 +16,5 @@ const (
 	MethodValJoinStatus jsonrpc.Method = "admin.val_join_status"
 	MethodValList       jsonrpc.Method = "admin.val_list"
 	MethodValListJoins  jsonrpc.Method = "admin.val_list_joins"
+	MethodPendingTxs    jsonrpc.Method = "admin.pending_txs"
 )
@@ -49,6 +49,10 @@ type PeersResponse struct {
 	Peers []*adminTypes.PeerInfo `json:"peers"`
 }
 
+type PendingTxsResponse struct {
+	Txs []*adminTypes.PendingTx `json:"txs"`
+}
+
 // type Peer = adminTypes.PeerInfo
 
 // type Peer struct {
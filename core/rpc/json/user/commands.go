@@ -72,6 +72,9 @@ type CallRequest = transactions.CallMessage
 // ChainInfoRequest contains the request parameters for MethodChainInfo.
 type ChainInfoRequest struct{}
 
+// ValidatorsRequest contains the request parameters for MethodValidators.
+type ValidatorsRequest struct{}
+
 // ListDatabasesRequest contains the request parameters for MethodDatabases.
 type ListDatabasesRequest struct {
 	Owner types.HexBytes `json:"owner,omitempty"`
@@ -91,6 +94,17 @@ type EstimatePriceRequest struct {
 type QueryRequest struct {
 	DBID  string `json:"dbid"`
 	Query string `json:"query"`
+	// Params are the values of any named parameters in the query, which are
+	// bound by the server rather than interpolated into the query. The names
+	// include the $ prefix, e.g. "$id".
+	Params map[string]*transactions.EncodedValue `json:"params,omitempty" desc:"named query parameters"`
+	// PageSize, if positive, is the maximum number of rows to return. If the
+	// results are truncated, the response has a NextCursor to request the next
+	// page. A paged query must be a SELECT without its own LIMIT or OFFSET.
+	PageSize int64 `json:"page_size,omitempty" desc:"maximum number of rows to return"`
+	// Cursor is the NextCursor from the response for the previous page of the
+	// same query. It is opaque to the client.
+	Cursor string `json:"cursor,omitempty" desc:"continuation token from the previous page"`
 }
 
 // TxQueryRequest contains the request parameters for MethodTxQuery.
@@ -14,4 +14,5 @@ const (
 	MethodQuery       jsonrpc.Method = "user.query"
 	MethodTxQuery     jsonrpc.Method = "user.tx_query"
 	MethodSchema      jsonrpc.Method = "user.schema"
+	MethodValidators  jsonrpc.Method = "user.validators"
 )
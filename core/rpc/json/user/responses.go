@@ -37,7 +37,12 @@ type Result struct { // for other types, but embedding it is kinda annoying when
 type CallResponse Result
 
 // QueryResponse contains the response object for MethodQuery.
-type QueryResponse Result
+type QueryResponse struct {
+	Result []byte `json:"result,omitempty"`
+	// NextCursor is set if the request had a PageSize and there are more rows.
+	// It is used as the Cursor of the request for the next page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
 
 // ChainInfoResponse contains the response object for MethodChainInfo.
 type ChainInfoResponse = types.ChainInfo
@@ -47,6 +52,11 @@ type SchemaResponse struct {
 	Schema *types.Schema `json:"schema,omitempty"`
 }
 
+// ValidatorsResponse contains the response object for MethodValidators.
+type ValidatorsResponse struct {
+	Validators []*types.Validator `json:"validators,omitempty"`
+}
+
 // SchemaResponse contains the response object for MethodSchema.
 type ListDatabasesResponse struct {
 	Databases []*DatasetInfo `json:"databases,omitempty"`
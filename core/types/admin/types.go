@@ -51,6 +51,15 @@ type Status struct {
 	Validator *ValidatorInfo `json:"validator"`
 }
 
+// PendingTx describes a transaction in a node's mempool. The Sender and Nonce
+// are omitted if the transaction could not be decoded.
+type PendingTx struct {
+	Hash   types.HexBytes `json:"hash"`
+	Size   int            `json:"size"`
+	Sender types.HexBytes `json:"sender,omitempty"`
+	Nonce  uint64         `json:"nonce,omitempty"`
+}
+
 // PeerInfo describes a connected peer node.
 type PeerInfo struct {
 	NodeInfo   *NodeInfo `json:"node"`
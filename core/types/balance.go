@@ -0,0 +1,85 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// FormatBalance formats an integer amount of the smallest token unit as a
+// fixed-point decimal string with the given number of decimal places, e.g.
+// 1500000000000000000 with 18 decimals is "1.5". Trailing zeros in the
+// fractional part are trimmed, and the decimal point is omitted for whole
+// amounts. The result is exact; no rounding is performed. A nil balance is
+// formatted as "0".
+func FormatBalance(bal *big.Int, decimals uint8) string {
+	if bal == nil {
+		return "0"
+	}
+	if decimals == 0 {
+		return bal.String()
+	}
+
+	digits := new(big.Int).Abs(bal).String()
+	if len(digits) <= int(decimals) { // pad so there is a whole part
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-int(decimals)], digits[len(digits)-int(decimals):]
+	frac = strings.TrimRight(frac, "0")
+
+	var sb strings.Builder
+	if bal.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(whole)
+	if frac != "" {
+		sb.WriteByte('.')
+		sb.WriteString(frac)
+	}
+	return sb.String()
+}
+
+// ParseBalance parses a decimal string, such as "1.5", into an integer amount
+// of the smallest token unit with the given number of decimal places, e.g.
+// 1500000000000000000 with 18 decimals. This is the inverse of FormatBalance.
+// Rather than rounding, it is an error for the string to have more
+// significant fractional digits than decimals, since that amount cannot be
+// represented.
+func ParseBalance(s string, decimals uint8) (*big.Int, error) {
+	str := strings.TrimSpace(s)
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+
+	whole, frac, _ := strings.Cut(str, ".")
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("invalid balance %q", s)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return nil, fmt.Errorf("invalid balance %q", s)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("balance %q has more than %d decimal places", s, decimals)
+	}
+	frac += strings.Repeat("0", int(decimals)-len(frac))
+
+	bal, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok { // whole+frac is empty, e.g. "." with zero decimals
+		return nil, errors.New("invalid balance")
+	}
+	if neg {
+		bal.Neg(bal)
+	}
+	return bal, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
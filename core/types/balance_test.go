@@ -0,0 +1,83 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustBigInt(t *testing.T, s string) *big.Int {
+	b, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok)
+	return b
+}
+
+func Test_FormatParseBalance(t *testing.T) {
+	tests := []struct {
+		name     string
+		bal      string
+		decimals uint8
+		want     string
+	}{
+		{"zero", "0", 18, "0"},
+		{"zero no decimals", "0", 0, "0"},
+		{"whole", "2000000000000000000", 18, "2"},
+		{"fractional", "1500000000000000000", 18, "1.5"},
+		{"less than one", "1500", 6, "0.0015"},
+		{"smallest unit", "1", 18, "0.000000000000000001"},
+		{"no decimals", "12345", 0, "12345"},
+		{"negative", "-1500000", 6, "-1.5"},
+		{"large", "123456789012345678901234567890", 18, "123456789012.34567890123456789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bal := mustBigInt(t, tt.bal)
+			got := types.FormatBalance(bal, tt.decimals)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := types.ParseBalance(got, tt.decimals)
+			require.NoError(t, err)
+			assert.Equal(t, bal.String(), parsed.String())
+		})
+	}
+
+	assert.Equal(t, "0", types.FormatBalance(nil, 18))
+}
+
+func Test_ParseBalance(t *testing.T) {
+	tests := []struct {
+		in       string
+		decimals uint8
+		want     string // empty for error
+	}{
+		{"1.5", 18, "1500000000000000000"},
+		{"1.50000", 2, "150"}, // trailing zeros are not significant
+		{".5", 1, "5"},
+		{"5.", 1, "50"},
+		{"+7", 3, "7000"},
+		{" 42 ", 0, "42"},
+		{"1.25", 1, ""}, // too precise
+		{"1.5", 0, ""},
+		{"", 18, ""},
+		{".", 18, ""},
+		{"-", 18, ""},
+		{"1.2.3", 18, ""},
+		{"1e18", 18, ""},
+		{"abc", 18, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := types.ParseBalance(tt.in, tt.decimals)
+			if tt.want == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 // Client defines methods are used to talk to a Kwil provider.
 type Client interface {
+	ActionSignature(ctx context.Context, dbid string, action string) (*types.ActionSignature, error)
 	// DEPRECATED: Use Call instead.
 	CallAction(ctx context.Context, dbid string, action string, inputs []any) (*Records, error)
 	Call(ctx context.Context, dbid string, procedure string, inputs []any) (*Records, error)
@@ -28,11 +29,14 @@ type Client interface {
 	ExecuteAction(ctx context.Context, dbid string, action string, tuples [][]any, opts ...TxOpt) (transactions.TxHash, error)
 	Execute(ctx context.Context, dbid string, action string, tuples [][]any, opts ...TxOpt) (transactions.TxHash, error)
 	GetAccount(ctx context.Context, pubKey []byte, status types.AccountStatus) (*types.Account, error)
+	GetAccountStates(ctx context.Context, pubKey []byte) (*types.AccountStates, error)
 	GetSchema(ctx context.Context, dbid string) (*types.Schema, error)
 	ListDatabases(ctx context.Context, owner []byte) ([]*types.DatasetIdentifier, error)
 	Ping(ctx context.Context) (string, error)
-	Query(ctx context.Context, dbid string, query string) (*Records, error)
+	Query(ctx context.Context, dbid string, query string, opts ...QueryOpt) (*Records, error)
 	TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTxQueryResponse, error)
+	Validators(ctx context.Context) ([]*types.Validator, error)
 	WaitTx(ctx context.Context, txHash []byte, interval time.Duration) (*transactions.TcTxQueryResponse, error)
+	WaitConfirmations(ctx context.Context, txHash []byte, confirmations int64, interval time.Duration) (*transactions.TcTxQueryResponse, error)
 	Transfer(ctx context.Context, to []byte, amount *big.Int, opts ...TxOpt) (transactions.TxHash, error)
 }
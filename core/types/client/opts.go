@@ -3,6 +3,7 @@ package client
 import (
 	"math/big"
 	"net/http"
+	"time"
 
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
 	"github.com/kwilteam/kwil-db/core/log"
@@ -146,3 +147,42 @@ func WithSyncBroadcast(wait bool) TxOpt {
 		o.SyncBcast = wait
 	}
 }
+
+// QueryOptions are options used when making an ad-hoc query.
+type QueryOptions struct {
+	// Timeout limits the duration of the query request. Zero means no limit
+	// other than that of the context.
+	Timeout time.Duration
+
+	// AllowMutating skips the client-side check that rejects statements that
+	// would modify the database.
+	AllowMutating bool
+}
+
+// GetQueryOpts applies the options to a new QueryOptions.
+func GetQueryOpts(opts []QueryOpt) *QueryOptions {
+	queryOpts := &QueryOptions{}
+	for _, opt := range opts {
+		opt(queryOpts)
+	}
+	return queryOpts
+}
+
+// QueryOpt sets an option used when making an ad-hoc query.
+type QueryOpt func(*QueryOptions)
+
+// WithQueryTimeout sets a timeout for the query request.
+func WithQueryTimeout(timeout time.Duration) QueryOpt {
+	return func(o *QueryOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// AllowMutatingQuery skips the client-side check for statements that would
+// modify the database, such as INSERT, UPDATE, DELETE, and DDL. The node will
+// still reject them, but this permits statements the check misidentifies.
+func AllowMutatingQuery() QueryOpt {
+	return func(o *QueryOptions) {
+		o.AllowMutating = true
+	}
+}
@@ -14,21 +14,34 @@ func (hb HexBytes) String() string {
 	return hex.EncodeToString(hb)
 }
 
-// UnmarshalText satisfies the json.Unmarshaler interface.
+// UnmarshalJSON satisfies the json.Unmarshaler interface. The JSON value must
+// be a string of an even number of hexadecimal digits, without a 0x prefix.
 func (hb *HexBytes) UnmarshalJSON(b []byte) error {
 	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
 		return fmt.Errorf("invalid hex string: %s", b)
 	}
 	sub := b[1 : len(b)-1] // strip the quotes
+	if len(sub)%2 != 0 {
+		return fmt.Errorf("invalid hex string %s: odd length %d", b, len(sub))
+	}
 	dec := make([]byte, hex.DecodedLen(len(sub)))
 	_, err := hex.Decode(dec, sub)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid hex string %s: %w", b, err)
 	}
 	*hb = dec
 	return nil
 }
 
+// ValidateLen returns an error if the decoded bytes are not n bytes long, such
+// as 32 for an ed25519 public key or 33 for a compressed secp256k1 public key.
+func (hb HexBytes) ValidateLen(n int) error {
+	if len(hb) != n {
+		return fmt.Errorf("expected %d bytes, got %d", n, len(hb))
+	}
+	return nil
+}
+
 // MarshalJSON satisfies the json.Marshaler interface.
 func (hb HexBytes) MarshalJSON() ([]byte, error) {
 	s := make([]byte, 2+hex.EncodedLen(len(hb)))
@@ -0,0 +1,61 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexBytes_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    types.HexBytes
+		wantErr bool
+	}{
+		{"valid", `"0a0B"`, types.HexBytes{0x0a, 0x0b}, false},
+		{"empty", `""`, types.HexBytes{}, false},
+		{"odd length", `"abc"`, nil, true},
+		{"non-hex", `"zz"`, nil, true},
+		{"prefixed", `"0x0a"`, nil, true},
+		{"not a string", `10`, nil, true},
+		{"escaped", `"\u0030\u0030"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hb types.HexBytes
+			err := json.Unmarshal([]byte(tt.in), &hb)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hb)
+		})
+	}
+
+	// Malformed identifiers in a struct are rejected.
+	var acct types.Account
+	err := json.Unmarshal([]byte(`{"identifier": "abc"}`), &acct)
+	require.ErrorContains(t, err, "odd length")
+
+	// Round trip.
+	hb := types.HexBytes{1, 2, 3}
+	b, err := json.Marshal(hb)
+	require.NoError(t, err)
+	var hb2 types.HexBytes
+	require.NoError(t, json.Unmarshal(b, &hb2))
+	assert.Equal(t, hb, hb2)
+}
+
+func TestHexBytes_ValidateLen(t *testing.T) {
+	hb := make(types.HexBytes, 33)
+	assert.NoError(t, hb.ValidateLen(33))
+	assert.Error(t, hb.ValidateLen(32))
+	assert.Error(t, hb.ValidateLen(65))
+	assert.NoError(t, types.HexBytes(nil).ValidateLen(0))
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// maxNonceGaps is the most missing nonces that CheckNonceSequence will list.
+const maxNonceGaps = 1000
+
+// CheckNonceSequence checks if a set of transaction nonces, in any order, can
+// be applied in sequence to an account with the given current nonce. The
+// account's current nonce is the nonce of its last transaction, as in
+// Account.Nonce, so the first nonce in the sequence should be current+1.
+//
+// The returned gaps are the nonces that are missing to form a gapless sequence
+// from current+1 up to the highest nonce provided. An error is returned if any
+// nonce is repeated or was already used by the account, or if there are more
+// than 1000 missing nonces, rather than listing them all.
+func CheckNonceSequence(current int64, txNonces []uint64) (gaps []uint64, err error) {
+	if current < 0 {
+		return nil, fmt.Errorf("invalid current nonce %d", current)
+	}
+	if len(txNonces) == 0 {
+		return nil, nil
+	}
+
+	nonces := slices.Clone(txNonces)
+	slices.Sort(nonces)
+
+	// Check every nonce for reuse before looking at the gaps, so that a
+	// repeated nonce is reported even when it follows a large gap.
+	for i, nonce := range nonces {
+		if nonce <= uint64(current) {
+			return nil, fmt.Errorf("nonce %d already used, account nonce is %d", nonce, current)
+		}
+		if i > 0 && nonce == nonces[i-1] {
+			return nil, fmt.Errorf("duplicate nonce %d", nonce)
+		}
+	}
+
+	next := uint64(current) + 1
+	for _, nonce := range nonces {
+		if nonce-next > uint64(maxNonceGaps-len(gaps)) {
+			return nil, fmt.Errorf("more than %d nonces missing before nonce %d", maxNonceGaps, nonce)
+		}
+		for ; next < nonce; next++ {
+			gaps = append(gaps, next)
+		}
+		if nonce < math.MaxUint64 { // no nonce can follow the highest one
+			next = nonce + 1
+		}
+	}
+
+	return gaps, nil
+}
@@ -0,0 +1,125 @@
+package types_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckNonceSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int64
+		nonces   []uint64
+		wantGaps []uint64
+		wantErr  bool
+	}{
+		{
+			name:    "empty",
+			current: 3,
+		},
+		{
+			name:    "contiguous",
+			current: 3,
+			nonces:  []uint64{4, 5, 6},
+		},
+		{
+			name:    "contiguous from new account",
+			current: 0,
+			nonces:  []uint64{1, 2},
+		},
+		{
+			name:     "gapped",
+			current:  3,
+			nonces:   []uint64{4, 7, 9},
+			wantGaps: []uint64{5, 6, 8},
+		},
+		{
+			name:     "first nonce missing",
+			current:  3,
+			nonces:   []uint64{5},
+			wantGaps: []uint64{4},
+		},
+		{
+			name:    "out of order",
+			current: 3,
+			nonces:  []uint64{6, 4, 5},
+		},
+		{
+			name:     "out of order with gap",
+			current:  3,
+			nonces:   []uint64{8, 4, 6},
+			wantGaps: []uint64{5, 7},
+		},
+		{
+			name:    "already used",
+			current: 3,
+			nonces:  []uint64{3, 4},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			current: 3,
+			nonces:  []uint64{4, 5, 4},
+			wantErr: true,
+		},
+		{
+			name:    "max nonce",
+			current: 3,
+			nonces:  []uint64{4, math.MaxUint64},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate last",
+			current: 3,
+			nonces:  []uint64{4, 5, 6, 6},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate max nonce last",
+			current: 3,
+			nonces:  []uint64{4, math.MaxUint64, math.MaxUint64},
+			wantErr: true,
+		},
+		{
+			name:    "too many gaps",
+			current: 0,
+			nonces:  []uint64{1, 3, 1004},
+			wantErr: true,
+		},
+		{
+			name:    "negative current",
+			current: -1,
+			nonces:  []uint64{1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonces := slices.Clone(tt.nonces)
+			gaps, err := types.CheckNonceSequence(tt.current, tt.nonces)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGaps, gaps)
+			assert.Equal(t, nonces, tt.nonces) // input not reordered
+		})
+	}
+
+	// A repeated nonce is reported even after a gap that is too large, and
+	// when it is the last entry.
+	_, err := types.CheckNonceSequence(3, []uint64{4, math.MaxUint64, math.MaxUint64})
+	require.ErrorContains(t, err, "duplicate nonce")
+
+	// Up to 1000 gaps are listed.
+	gaps, err := types.CheckNonceSequence(0, []uint64{1, 3, 1003})
+	require.NoError(t, err)
+	assert.Len(t, gaps, 1000)
+}
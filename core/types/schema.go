@@ -8,7 +8,6 @@ import (
 
 	"github.com/kwilteam/kwil-db/core/types/decimal"
 	"github.com/kwilteam/kwil-db/core/types/validation"
-	"github.com/kwilteam/kwil-db/core/utils"
 )
 
 // Schema is a database schema that contains tables, procedures, and extensions.
@@ -143,6 +142,46 @@ func (s *Schema) FindProcedure(name string) (procedure *Procedure, found bool) {
 	return nil, false
 }
 
+// ActionSignature returns the signature of an action or procedure based on its
+// name. It returns false if there is no action or procedure with the name.
+func (s *Schema) ActionSignature(name string) (sig *ActionSignature, found bool) {
+	if act, ok := s.FindAction(name); ok {
+		sig = &ActionSignature{
+			Name:       act.Name,
+			Public:     act.Public,
+			Parameters: make([]*ActionParameter, len(act.Parameters)),
+		}
+		for i, param := range act.Parameters {
+			sig.Parameters[i] = &ActionParameter{
+				Name:     param,
+				Nullable: true,
+			}
+		}
+		return sig, true
+	}
+
+	if proc, ok := s.FindProcedure(name); ok {
+		sig = &ActionSignature{
+			Name:        proc.Name,
+			Public:      proc.Public,
+			IsProcedure: true,
+			Parameters:  make([]*ActionParameter, len(proc.Parameters)),
+		}
+		for i, param := range proc.Parameters {
+			sig.Parameters[i] = &ActionParameter{
+				Name:     param.Name,
+				Nullable: true,
+			}
+			if param.Type != nil {
+				sig.Parameters[i].Type = param.Type.Copy()
+			}
+		}
+		return sig, true
+	}
+
+	return nil, false
+}
+
 // FindForeignProcedure finds a foreign procedure based on its name.
 // It returns false if the procedure is not found.
 func (s *Schema) FindForeignProcedure(name string) (procedure *ForeignProcedure, found bool) {
@@ -168,7 +207,7 @@ func (s *Schema) FindExtensionImport(alias string) (extension *Extension, found
 }
 
 func (s *Schema) DBID() string {
-	return utils.GenerateDBID(s.Name, s.Owner)
+	return GenerateDBID(s.Name, s.Owner)
 }
 
 // Table is a table in a database schema.
@@ -1016,6 +1055,33 @@ func (p *ProcedureReturn) Copy() *ProcedureReturn {
 	}
 }
 
+// ActionSignature describes the inputs of an action or procedure, which are
+// the values of each tuple when executing or calling it.
+type ActionSignature struct {
+	// Name is the name of the action or procedure.
+	Name string `json:"name"`
+	// Public is true if the action or procedure may be called by anyone.
+	Public bool `json:"public"`
+	// IsProcedure is true for a procedure, which has typed parameters, and
+	// false for an action.
+	IsProcedure bool `json:"is_procedure"`
+	// Parameters are the parameters in the order they are passed.
+	Parameters []*ActionParameter `json:"parameters"`
+}
+
+// ActionParameter is a parameter of an action or procedure.
+type ActionParameter struct {
+	// Name is the name of the parameter. Action parameters include the
+	// leading $.
+	Name string `json:"name"`
+	// Type is the declared type of the parameter. It is nil for action
+	// parameters, which are untyped.
+	Type *DataType `json:"type,omitempty"`
+	// Nullable is true if NULL may be passed for the parameter. Neither
+	// actions nor procedures can currently declare a parameter as NOT NULL.
+	Nullable bool `json:"nullable"`
+}
+
 // ProcedureParameter is a parameter in a procedure.
 type ProcedureParameter struct {
 	// Name is the name of the parameter.
@@ -0,0 +1,270 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SchemaDiff describes the structural differences between two schemas. All
+// names are lower case and sorted. See DiffSchemas.
+type SchemaDiff struct {
+	AddedTables    []string     `json:"added_tables,omitempty"`
+	DroppedTables  []string     `json:"dropped_tables,omitempty"`
+	ModifiedTables []*TableDiff `json:"modified_tables,omitempty"`
+
+	AddedActions    []string `json:"added_actions,omitempty"`
+	DroppedActions  []string `json:"dropped_actions,omitempty"`
+	ModifiedActions []string `json:"modified_actions,omitempty"`
+
+	AddedProcedures    []string `json:"added_procedures,omitempty"`
+	DroppedProcedures  []string `json:"dropped_procedures,omitempty"`
+	ModifiedProcedures []string `json:"modified_procedures,omitempty"`
+}
+
+// Empty returns true if there are no differences.
+func (d *SchemaDiff) Empty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.ModifiedTables) == 0 &&
+		len(d.AddedActions) == 0 && len(d.DroppedActions) == 0 && len(d.ModifiedActions) == 0 &&
+		len(d.AddedProcedures) == 0 && len(d.DroppedProcedures) == 0 && len(d.ModifiedProcedures) == 0
+}
+
+// TableDiff describes the differences in a table that is in both schemas.
+// Foreign keys have no names, so they are described by their definitions, e.g.
+// "(a) references tbl2(b) on_delete cascade".
+type TableDiff struct {
+	Name string `json:"name"`
+
+	AddedColumns    []string `json:"added_columns,omitempty"`
+	DroppedColumns  []string `json:"dropped_columns,omitempty"`
+	ModifiedColumns []string `json:"modified_columns,omitempty"`
+
+	AddedIndexes    []string `json:"added_indexes,omitempty"`
+	DroppedIndexes  []string `json:"dropped_indexes,omitempty"`
+	ModifiedIndexes []string `json:"modified_indexes,omitempty"`
+
+	AddedForeignKeys   []string `json:"added_foreign_keys,omitempty"`
+	DroppedForeignKeys []string `json:"dropped_foreign_keys,omitempty"`
+}
+
+func (d *TableDiff) empty() bool {
+	return len(d.AddedColumns) == 0 && len(d.DroppedColumns) == 0 && len(d.ModifiedColumns) == 0 &&
+		len(d.AddedIndexes) == 0 && len(d.DroppedIndexes) == 0 && len(d.ModifiedIndexes) == 0 &&
+		len(d.AddedForeignKeys) == 0 && len(d.DroppedForeignKeys) == 0
+}
+
+// DiffSchemas reports the structural differences between an old schema, such
+// as one that is already deployed, and a new one: the added, dropped, and
+// modified tables, actions, and procedures, and for modified tables, the
+// changes to columns, indexes, and foreign keys. Names are compared without
+// regard to case, and the order of tables, columns, actions, etc. in the
+// schema does not matter. The order of index columns, foreign key columns, and
+// action or procedure parameters does matter. Extensions and foreign
+// procedures are not compared.
+func DiffSchemas(oldSchema, newSchema *Schema) (*SchemaDiff, error) {
+	if oldSchema == nil || newSchema == nil {
+		return nil, errors.New("cannot diff a nil schema")
+	}
+
+	diff := &SchemaDiff{}
+	var modifiedTables []string
+	var err error
+	diff.AddedTables, diff.DroppedTables, modifiedTables, err = diffNamed(oldSchema.Tables, newSchema.Tables,
+		func(t *Table) string { return t.Name }, func(a, b *Table) bool {
+			d, err := diffTables(a, b)
+			if err != nil {
+				return false // treat as modified so the error is returned below
+			}
+			return d.empty()
+		})
+	if err != nil {
+		return nil, fmt.Errorf("tables: %w", err)
+	}
+	for _, name := range modifiedTables {
+		oldTbl, _ := oldSchema.FindTable(name)
+		newTbl, _ := newSchema.FindTable(name)
+		tblDiff, err := diffTables(oldTbl, newTbl)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		diff.ModifiedTables = append(diff.ModifiedTables, tblDiff)
+	}
+
+	diff.AddedActions, diff.DroppedActions, diff.ModifiedActions, err = diffNamed(oldSchema.Actions, newSchema.Actions,
+		func(a *Action) string { return a.Name }, actionsEqual)
+	if err != nil {
+		return nil, fmt.Errorf("actions: %w", err)
+	}
+
+	diff.AddedProcedures, diff.DroppedProcedures, diff.ModifiedProcedures, err = diffNamed(oldSchema.Procedures, newSchema.Procedures,
+		func(p *Procedure) string { return p.Name }, proceduresEqual)
+	if err != nil {
+		return nil, fmt.Errorf("procedures: %w", err)
+	}
+
+	return diff, nil
+}
+
+// diffTables compares two tables with the same name.
+func diffTables(oldTbl, newTbl *Table) (*TableDiff, error) {
+	diff := &TableDiff{Name: strings.ToLower(newTbl.Name)}
+
+	var err error
+	diff.AddedColumns, diff.DroppedColumns, diff.ModifiedColumns, err = diffNamed(oldTbl.Columns, newTbl.Columns,
+		func(c *Column) string { return c.Name }, columnsEqual)
+	if err != nil {
+		return nil, fmt.Errorf("columns: %w", err)
+	}
+
+	diff.AddedIndexes, diff.DroppedIndexes, diff.ModifiedIndexes, err = diffNamed(oldTbl.Indexes, newTbl.Indexes,
+		func(i *Index) string { return i.Name }, indexesEqual)
+	if err != nil {
+		return nil, fmt.Errorf("indexes: %w", err)
+	}
+
+	diff.AddedForeignKeys, diff.DroppedForeignKeys = diffSets(
+		mapSlice(oldTbl.ForeignKeys, foreignKeyString), mapSlice(newTbl.ForeignKeys, foreignKeyString))
+
+	return diff, nil
+}
+
+// diffNamed compares two slices of named items, returning the sorted lower
+// case names of the items that were added, dropped, and modified. An error is
+// returned if either slice has more than one item with the same name.
+func diffNamed[T any](oldItems, newItems []T, name func(T) string, equal func(a, b T) bool) (added, dropped, modified []string, err error) {
+	byName := func(items []T) (map[string]T, error) {
+		m := make(map[string]T, len(items))
+		for _, item := range items {
+			n := strings.ToLower(name(item))
+			if _, have := m[n]; have {
+				return nil, fmt.Errorf("duplicate name %q", n)
+			}
+			m[n] = item
+		}
+		return m, nil
+	}
+
+	oldByName, err := byName(oldItems)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	newByName, err := byName(newItems)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for n, newItem := range newByName {
+		oldItem, have := oldByName[n]
+		if !have {
+			added = append(added, n)
+		} else if !equal(oldItem, newItem) {
+			modified = append(modified, n)
+		}
+	}
+	for n := range oldByName {
+		if _, have := newByName[n]; !have {
+			dropped = append(dropped, n)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(dropped)
+	slices.Sort(modified)
+	return added, dropped, modified, nil
+}
+
+// diffSets returns the sorted items that are only in b (added) and only in a
+// (dropped). Duplicates are counted, so a set with an extra copy of an item
+// has that item added.
+func diffSets(a, b []string) (added, dropped []string) {
+	counts := make(map[string]int)
+	for _, s := range a {
+		counts[s]--
+	}
+	for _, s := range b {
+		counts[s]++
+	}
+	for s, n := range counts {
+		for ; n > 0; n-- {
+			added = append(added, s)
+		}
+		for ; n < 0; n++ {
+			dropped = append(dropped, s)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(dropped)
+	return added, dropped
+}
+
+// sameSet checks if two slices have the same items, ignoring order.
+func sameSet(a, b []string) bool {
+	added, dropped := diffSets(a, b)
+	return len(added) == 0 && len(dropped) == 0
+}
+
+func mapSlice[T any, U any](s []T, fn func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+func lowerAll(s []string) []string {
+	return mapSlice(s, strings.ToLower)
+}
+
+func columnsEqual(a, b *Column) bool {
+	if (a.Type == nil) != (b.Type == nil) {
+		return false
+	}
+	if a.Type != nil && !a.Type.EqualsStrict(b.Type) {
+		return false
+	}
+	attrString := func(attr *Attribute) string {
+		return strings.ToLower(string(attr.Type)) + "=" + attr.Value
+	}
+	return sameSet(mapSlice(a.Attributes, attrString), mapSlice(b.Attributes, attrString))
+}
+
+func indexesEqual(a, b *Index) bool {
+	return strings.EqualFold(string(a.Type), string(b.Type)) &&
+		slices.Equal(lowerAll(a.Columns), lowerAll(b.Columns))
+}
+
+func foreignKeyString(fk *ForeignKey) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "(%s) references %s(%s)", strings.Join(lowerAll(fk.ChildKeys), ", "),
+		strings.ToLower(fk.ParentTable), strings.Join(lowerAll(fk.ParentKeys), ", "))
+
+	actions := mapSlice(fk.Actions, func(a *ForeignKeyAction) string {
+		return strings.ToLower(string(a.On)) + " " + strings.ToLower(string(a.Do))
+	})
+	slices.Sort(actions)
+	for _, a := range actions {
+		sb.WriteString(" " + a)
+	}
+	return sb.String()
+}
+
+func modifierStrings(mods []Modifier) []string {
+	return mapSlice(mods, func(m Modifier) string { return strings.ToLower(string(m)) })
+}
+
+func actionsEqual(a, b *Action) bool {
+	return a.Public == b.Public && a.Body == b.Body &&
+		slices.Equal(lowerAll(a.Parameters), lowerAll(b.Parameters)) &&
+		sameSet(a.Annotations, b.Annotations) &&
+		sameSet(modifierStrings(a.Modifiers), modifierStrings(b.Modifiers))
+}
+
+func proceduresEqual(a, b *Procedure) bool {
+	return a.Public == b.Public && a.Body == b.Body &&
+		reflect.DeepEqual(a.Parameters, b.Parameters) &&
+		reflect.DeepEqual(a.Returns, b.Returns) &&
+		sameSet(a.Annotations, b.Annotations) &&
+		sameSet(modifierStrings(a.Modifiers), modifierStrings(b.Modifiers))
+}
@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/testdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copySchema deep copies the test schema.
+func copySchema(t *testing.T) *types.Schema {
+	bts, err := json.Marshal(testdata.TestSchema)
+	require.NoError(t, err)
+	var s types.Schema
+	require.NoError(t, json.Unmarshal(bts, &s))
+	return &s
+}
+
+func Test_DiffSchemas(t *testing.T) {
+	t.Run("identical", func(t *testing.T) {
+		diff, err := types.DiffSchemas(testdata.TestSchema, copySchema(t))
+		require.NoError(t, err)
+		assert.True(t, diff.Empty())
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		s := copySchema(t)
+		slices.Reverse(s.Tables)
+		slices.Reverse(s.Actions)
+		slices.Reverse(s.Procedures)
+		for _, tbl := range s.Tables {
+			slices.Reverse(tbl.Columns)
+			slices.Reverse(tbl.Indexes)
+			slices.Reverse(tbl.ForeignKeys)
+			for _, col := range tbl.Columns {
+				slices.Reverse(col.Attributes)
+			}
+		}
+		for _, act := range s.Actions {
+			slices.Reverse(act.Modifiers)
+		}
+
+		diff, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.NoError(t, err)
+		assert.True(t, diff.Empty(), "unexpected diff: %+v", diff)
+	})
+
+	t.Run("added column", func(t *testing.T) {
+		s := copySchema(t)
+		s.Tables[0].Columns = append(s.Tables[0].Columns, &types.Column{
+			Name: "Bio",
+			Type: types.TextType,
+		})
+
+		diff, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.NoError(t, err)
+		require.False(t, diff.Empty())
+		require.Len(t, diff.ModifiedTables, 1)
+		assert.Equal(t, s.Tables[0].Name, diff.ModifiedTables[0].Name)
+		assert.Equal(t, []string{"bio"}, diff.ModifiedTables[0].AddedColumns)
+		assert.Empty(t, diff.ModifiedTables[0].DroppedColumns)
+		assert.Empty(t, diff.ModifiedTables[0].ModifiedColumns)
+		assert.Empty(t, diff.AddedTables)
+		assert.Empty(t, diff.DroppedTables)
+	})
+
+	t.Run("dropped table", func(t *testing.T) {
+		s := copySchema(t)
+		dropped := s.Tables[1].Name
+		s.Tables = s.Tables[:1]
+
+		diff, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.NoError(t, err)
+		assert.Equal(t, []string{dropped}, diff.DroppedTables)
+		assert.Empty(t, diff.AddedTables)
+		assert.Empty(t, diff.ModifiedTables)
+
+		// and the other way around
+		diff, err = types.DiffSchemas(s, testdata.TestSchema)
+		require.NoError(t, err)
+		assert.Equal(t, []string{dropped}, diff.AddedTables)
+	})
+
+	t.Run("modified action", func(t *testing.T) {
+		s := copySchema(t)
+		s.Actions[0].Body += "\nSELECT 1;"
+		s.Actions = s.Actions[:len(s.Actions)-1]
+
+		diff, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.NoError(t, err)
+		assert.Equal(t, []string{s.Actions[0].Name}, diff.ModifiedActions)
+		assert.Equal(t, []string{testdata.TestSchema.Actions[len(testdata.TestSchema.Actions)-1].Name}, diff.DroppedActions)
+	})
+
+	t.Run("duplicate table", func(t *testing.T) {
+		s := copySchema(t)
+		s.Tables = append(s.Tables, s.Tables[0])
+		_, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate column", func(t *testing.T) {
+		s := copySchema(t)
+		col := *s.Tables[0].Columns[0]
+		col.Name = strings.ToUpper(col.Name)
+		s.Tables[0].Columns = append(s.Tables[0].Columns, &col)
+
+		_, err := types.DiffSchemas(testdata.TestSchema, s)
+		require.ErrorContains(t, err, "duplicate name")
+		_, err = types.DiffSchemas(s, testdata.TestSchema)
+		require.ErrorContains(t, err, "duplicate name")
+	})
+}
@@ -0,0 +1,68 @@
+package serialize
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrTooLarge is returned by DecodeFrom when the data in the stream exceeds
+// the size limit.
+var ErrTooLarge = errors.New("serialized data exceeds size limit")
+
+// DecodeFrom decodes one serialized value from the stream into v, which should
+// be a pointer, and returns the number of bytes read. Only the RLP encoding is
+// supported. Unlike Decode, the data does not need to be buffered first. No
+// more than limit bytes (including the two byte encoding type prefix) are
+// read, and every length prefix in the data is checked against the remaining
+// limit before anything is allocated for it, so a malicious length cannot
+// cause a large allocation. No bytes beyond the end of the value are read from
+// r, so multiple values may be decoded from the same stream.
+func DecodeFrom(r io.Reader, v any, limit int64) (int64, error) {
+	if err := requireNonNilPointer(v); err != nil {
+		return 0, err
+	}
+	if limit < 3 {
+		return 0, ErrTooLarge
+	}
+
+	cr := &countingByteReader{r: r}
+	var prefix [2]byte
+	if _, err := io.ReadFull(cr, prefix[:]); err != nil {
+		return cr.n, err
+	}
+	if encType := binary.BigEndian.Uint16(prefix[:]); encType != encodingTypeRLP {
+		return cr.n, fmt.Errorf("unsupported encoding type %v for stream decoding", encType)
+	}
+
+	err := rlp.NewStream(cr, uint64(limit-cr.n)).Decode(v)
+	if errors.Is(err, rlp.ErrValueTooLarge) {
+		err = fmt.Errorf("%w: %w", ErrTooLarge, err)
+	}
+	return cr.n, err
+}
+
+// countingByteReader counts the bytes read from the underlying reader. It
+// implements io.ByteReader so that the rlp.Stream does not add its own
+// buffering, which would read past the end of the value.
+type countingByteReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingByteReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *countingByteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(cr, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
@@ -0,0 +1,107 @@
+package transactions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+)
+
+// TxBuildOpt is an option for NewTransaction.
+type TxBuildOpt func(*txBuildOpts)
+
+type txBuildOpts struct {
+	chainID     string
+	nonce       uint64
+	fee         *big.Int
+	description string
+	validUntil  uint64
+	signer      auth.Signer
+}
+
+// WithChainID sets the chain ID of the transaction.
+func WithChainID(chainID string) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.chainID = chainID
+	}
+}
+
+// WithNonce sets the nonce of the transaction, which should be one more than
+// the sender's latest nonce.
+func WithNonce(nonce uint64) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.nonce = nonce
+	}
+}
+
+// WithFee sets the fee of the transaction. The default is zero.
+func WithFee(fee *big.Int) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.fee = fee
+	}
+}
+
+// WithDescription sets the human-readable description of the transaction.
+func WithDescription(desc string) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.description = desc
+	}
+}
+
+// WithValidUntilHeight sets the last block height at which the transaction may
+// be included in a block. The default is zero, which means no expiry.
+func WithValidUntilHeight(height uint64) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.validUntil = height
+	}
+}
+
+// WithSigner sets the Signer used to sign the transaction after it is built.
+// If no signer is provided, the transaction is left unsigned.
+func WithSigner(signer auth.Signer) TxBuildOpt {
+	return func(o *txBuildOpts) {
+		o.signer = signer
+	}
+}
+
+// NewTransaction builds a transaction with the given payload. The payload is
+// serialized and its type set in the transaction body. The chain ID, nonce,
+// fee, and description are set from the options. If a signer is provided with
+// WithSigner, the transaction is signed and ready to broadcast.
+func NewTransaction(payload Payload, opts ...TxBuildOpt) (*Transaction, error) {
+	if payload == nil {
+		return nil, errors.New("payload is required")
+	}
+
+	o := &txBuildOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(o.description) > MsgDescriptionMaxLength {
+		return nil, fmt.Errorf("description length %d exceeds the maximum of %d",
+			len(o.description), MsgDescriptionMaxLength)
+	}
+	if o.fee != nil && o.fee.Sign() < 0 {
+		return nil, errors.New("fee must not be negative")
+	}
+
+	tx, err := CreateTransaction(payload, o.chainID, o.nonce)
+	if err != nil {
+		return nil, err
+	}
+	tx.Body.Description = o.description
+	tx.Body.ValidUntilHeight = o.validUntil
+	if o.fee != nil {
+		tx.Body.Fee = new(big.Int).Set(o.fee)
+	}
+
+	if o.signer != nil {
+		if err = tx.Sign(o.signer); err != nil {
+			return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		}
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,72 @@
+package transactions_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransaction(t *testing.T) {
+	secpKey, err := crypto.GenerateSecp256k1Key()
+	require.NoError(t, err)
+	signer := &auth.EthPersonalSigner{Key: *secpKey}
+
+	payload := &transactions.ActionExecution{
+		DBID:   "xdbid",
+		Action: "insert_user",
+		Arguments: [][]*transactions.EncodedValue{
+			{mustEncode(t, "alice"), mustEncode(t, int64(42))},
+		},
+	}
+
+	fee := big.NewInt(1234)
+	tx, err := transactions.NewTransaction(payload,
+		transactions.WithChainID("kwil-test-chain"),
+		transactions.WithNonce(7),
+		transactions.WithFee(fee),
+		transactions.WithDescription("add alice"),
+		transactions.WithSigner(signer),
+	)
+	require.NoError(t, err)
+
+	fee.SetInt64(1) // the tx has its own copy
+	require.Equal(t, big.NewInt(1234), tx.Body.Fee)
+	require.Equal(t, "kwil-test-chain", tx.Body.ChainID)
+	require.Equal(t, uint64(7), tx.Body.Nonce)
+	require.Equal(t, "add alice", tx.Body.Description)
+	require.Equal(t, transactions.PayloadTypeExecute, tx.Body.PayloadType)
+	require.Equal(t, signer.Identity(), []byte(tx.Sender))
+	require.NoError(t, tx.Verify())
+
+	var decoded transactions.ActionExecution
+	require.NoError(t, decoded.UnmarshalBinary(tx.Body.Payload))
+	require.Equal(t, payload, &decoded)
+
+	// Without a signer, the transaction is not signed.
+	tx, err = transactions.NewTransaction(payload, transactions.WithChainID("kwil-test-chain"))
+	require.NoError(t, err)
+	require.Nil(t, tx.Signature)
+	require.Equal(t, big.NewInt(0), tx.Body.Fee)
+
+	_, err = transactions.NewTransaction(payload, transactions.WithFee(big.NewInt(-1)))
+	require.Error(t, err)
+
+	long := strings.Repeat("x", transactions.MsgDescriptionMaxLength+1)
+	_, err = transactions.NewTransaction(payload, transactions.WithDescription(long))
+	require.Error(t, err)
+
+	_, err = transactions.NewTransaction(nil)
+	require.Error(t, err)
+}
+
+func mustEncode(t *testing.T, v any) *transactions.EncodedValue {
+	ev, err := transactions.EncodeValue(v)
+	require.NoError(t, err)
+	return ev
+}
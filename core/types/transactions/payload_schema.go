@@ -1,6 +1,9 @@
 package transactions
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/kwilteam/kwil-db/core/types"
 	"github.com/kwilteam/kwil-db/core/types/serialize"
 )
@@ -15,6 +18,37 @@ func (s *Schema) UnmarshalBinary(b serialize.SerializedData) error {
 	return serialize.Decode(b, s)
 }
 
+// Validate checks that the schema is named and that none of its tables,
+// actions, procedures, or extensions are nil. It does not check that the
+// schema is valid for deployment.
+func (s *Schema) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: missing schema name", ErrMalformedPayload)
+	}
+	if err := noNils("table", s.Tables); err != nil {
+		return err
+	}
+	if err := noNils("action", s.Actions); err != nil {
+		return err
+	}
+	if err := noNils("procedure", s.Procedures); err != nil {
+		return err
+	}
+	if err := noNils("extension", s.Extensions); err != nil {
+		return err
+	}
+	return noNils("foreign procedure", s.ForeignProcedures)
+}
+
+func noNils[T any](kind string, items []*T) error {
+	for i, item := range items {
+		if item == nil {
+			return fmt.Errorf("%w: nil %s at position %d", ErrMalformedPayload, kind, i)
+		}
+	}
+	return nil
+}
+
 func (s *Schema) Type() PayloadType {
 	return PayloadTypeDeploySchema
 }
@@ -412,6 +446,26 @@ func (f *ForeignProcedure) toTypes() *types.ForeignProcedure {
 	}
 }
 
+// DiffSchemas reports the structural differences between an old schema, such
+// as one that is already deployed, and a new one, such as a deploy_schema
+// payload. The schemas are converted to the core type, and compared as
+// described for types.DiffSchemas, which can be used with schemas already in
+// that form, like those returned by the GetSchema RPC.
+func DiffSchemas(oldSchema, newSchema *Schema) (*types.SchemaDiff, error) {
+	if oldSchema == nil || newSchema == nil {
+		return nil, errors.New("cannot diff a nil schema")
+	}
+	oldTypes, err := oldSchema.ToTypes()
+	if err != nil {
+		return nil, fmt.Errorf("old schema: %w", err)
+	}
+	newTypes, err := newSchema.ToTypes()
+	if err != nil {
+		return nil, fmt.Errorf("new schema: %w", err)
+	}
+	return types.DiffSchemas(oldTypes, newTypes)
+}
+
 // fromTypes converts the core type to the RLP serializable type.
 
 func (s *Schema) FromTypes(s2 *types.Schema) {
@@ -31,6 +31,7 @@ const (
 	PayloadTypeValidatorLeave      PayloadType = "validator_leave"
 	PayloadTypeValidatorRemove     PayloadType = "validator_remove"
 	PayloadTypeValidatorApprove    PayloadType = "validator_approve"
+	PayloadTypeValidatorPower      PayloadType = "validator_power" // registered by kwild's "validator_power" hardfork
 	PayloadTypeValidatorVoteIDs    PayloadType = "validator_vote_ids"
 	PayloadTypeValidatorVoteBodies PayloadType = "validator_vote_bodies"
 )
@@ -45,15 +46,36 @@ var payloadConcreteTypes = map[PayloadType]Payload{
 	PayloadTypeValidatorJoin:       &ValidatorJoin{},
 	PayloadTypeValidatorApprove:    &ValidatorApprove{},
 	PayloadTypeValidatorRemove:     &ValidatorRemove{},
+	PayloadTypeValidatorPower:      &ValidatorUpdatePower{},
 	PayloadTypeValidatorLeave:      &ValidatorLeave{},
 	PayloadTypeTransfer:            &Transfer{},
 	PayloadTypeValidatorVoteIDs:    &ValidatorVoteIDs{},
 	PayloadTypeValidatorVoteBodies: &ValidatorVoteBodies{},
 }
 
+// ErrMalformedPayload indicates that a payload was decoded, but the result is
+// not a well-formed payload, such as an action execution with no action name.
+var ErrMalformedPayload = errors.New("malformed payload")
+
 // UnmarshalPayload unmarshals a serialized transaction payload into an instance
-// of the type registered for the given PayloadType.
+// of the type registered for the given PayloadType. An error wrapping
+// ErrMalformedPayload is returned if the decoded payload is not well-formed
+// according to ValidatePayload.
 func UnmarshalPayload(payloadType PayloadType, payload []byte) (Payload, error) {
+	payloadIface, err := DecodePayload(payloadType, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err = ValidatePayload(payloadIface); err != nil {
+		return nil, err
+	}
+	return payloadIface, nil
+}
+
+// DecodePayload is like UnmarshalPayload, but it does not check the decoded
+// payload with ValidatePayload. This is for displaying transactions that may
+// already be committed, which need not be well-formed.
+func DecodePayload(payloadType PayloadType, payload []byte) (Payload, error) {
 	prototype, have := payloadConcreteTypes[payloadType]
 	if !have {
 		return nil, errors.New("unknown payload type")
@@ -63,17 +85,39 @@ func UnmarshalPayload(payloadType PayloadType, payload []byte) (Payload, error)
 	elem := reflect.New(t)       // reflect.Type => reflect.Value
 	instance := elem.Interface() // reflect.Type => any
 
-	err := serialize.Decode(payload, instance)
-	if err != nil {
-		return nil, err
-	}
 	payloadIface, ok := instance.(Payload)
 	if !ok { // should be impossible since payloadConcreteTypes maps to a Payload
 		return nil, errors.New("instance not a payload")
 	}
+	if err := payloadIface.UnmarshalBinary(payload); err != nil {
+		return nil, err
+	}
 	return payloadIface, nil
 }
 
+// ValidatePayload checks that a decoded payload is well-formed, if its type
+// defines a Validate method. These checks are stricter than what was applied
+// when decoding payloads in existing blocks, so they are for clients and
+// mempool admission only. Block execution must not reject a transaction that
+// fails them, since that would change the results of historical transactions.
+func ValidatePayload(p Payload) error {
+	if v, ok := p.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// CheckPayload decodes a serialized payload of one of the built-in payload
+// types and checks it with ValidatePayload. Other payload types, such as those
+// added by extensions, are not checked.
+func CheckPayload(payloadType PayloadType, payload []byte) error {
+	if _, have := payloadConcreteTypes[payloadType]; !have {
+		return nil
+	}
+	_, err := UnmarshalPayload(payloadType, payload)
+	return err
+}
+
 // Valid says if the payload type is known. This does not mean that the node
 // will execute the transaction, e.g. not yet activated, or removed.
 func (p PayloadType) Valid() bool {
@@ -149,6 +193,14 @@ func (s *DropSchema) UnmarshalBinary(b serialize.SerializedData) error {
 	return serialize.Decode(b, s)
 }
 
+// Validate checks that the dbid is set.
+func (s *DropSchema) Validate() error {
+	if s.DBID == "" {
+		return fmt.Errorf("%w: missing dbid", ErrMalformedPayload)
+	}
+	return nil
+}
+
 func (s *DropSchema) Type() PayloadType {
 	return PayloadTypeDropSchema
 }
@@ -170,6 +222,30 @@ func (a *ActionExecution) UnmarshalBinary(b serialize.SerializedData) error {
 	return serialize.Decode(b, a)
 }
 
+// Validate checks that the dbid and action are set, and that the arguments for
+// each execution of the action are the same length and have no nil values.
+func (a *ActionExecution) Validate() error {
+	if a.DBID == "" {
+		return fmt.Errorf("%w: missing dbid", ErrMalformedPayload)
+	}
+	if a.Action == "" {
+		return fmt.Errorf("%w: missing action", ErrMalformedPayload)
+	}
+	for i, args := range a.Arguments {
+		if len(args) != len(a.Arguments[0]) {
+			return fmt.Errorf("%w: argument set %d has %d values, expected %d",
+				ErrMalformedPayload, i, len(args), len(a.Arguments[0]))
+		}
+		for j, arg := range args {
+			if arg == nil {
+				return fmt.Errorf("%w: argument set %d has nil value at position %d",
+					ErrMalformedPayload, i, j)
+			}
+		}
+	}
+	return nil
+}
+
 func (a *ActionExecution) Type() PayloadType {
 	return PayloadTypeExecute
 }
@@ -465,6 +541,23 @@ func (a *ActionCall) UnmarshalBinary(b serialize.SerializedData) error {
 	return serialize.Decode(b, a)
 }
 
+// Validate checks that the dbid and action are set, and that there are no nil
+// arguments.
+func (a *ActionCall) Validate() error {
+	if a.DBID == "" {
+		return fmt.Errorf("%w: missing dbid", ErrMalformedPayload)
+	}
+	if a.Action == "" {
+		return fmt.Errorf("%w: missing action", ErrMalformedPayload)
+	}
+	for i, arg := range a.Arguments {
+		if arg == nil {
+			return fmt.Errorf("%w: nil argument at position %d", ErrMalformedPayload, i)
+		}
+	}
+	return nil
+}
+
 var _ encoding.BinaryUnmarshaler = (*ActionCall)(nil)
 var _ encoding.BinaryMarshaler = (*ActionCall)(nil)
 
@@ -554,6 +647,55 @@ func (v *ValidatorRemove) MarshalBinary() ([]byte, error) {
 	return serialize.Encode(v)
 }
 
+// ValidatorUpdatePower is used to vote for a change to an existing validator's
+// power. The change is made when enough validators vote for the same target and
+// power. To remove a validator, use ValidatorRemove rather than a zero power.
+type ValidatorUpdatePower struct {
+	Target   types.HexBytes
+	NewPower int64
+}
+
+// validatorUpdatePowerRLP is the serialized form of ValidatorUpdatePower. RLP
+// has no signed integers, so the power is stored as a uint64.
+type validatorUpdatePowerRLP struct {
+	Target   []byte
+	NewPower uint64
+}
+
+var _ Payload = (*ValidatorUpdatePower)(nil)
+
+func (v *ValidatorUpdatePower) Type() PayloadType {
+	return PayloadTypeValidatorPower
+}
+
+func (v *ValidatorUpdatePower) MarshalBinary() (serialize.SerializedData, error) {
+	return serialize.Encode(&validatorUpdatePowerRLP{
+		Target:   v.Target,
+		NewPower: uint64(v.NewPower),
+	})
+}
+
+func (v *ValidatorUpdatePower) UnmarshalBinary(b serialize.SerializedData) error {
+	var vp validatorUpdatePowerRLP
+	if err := serialize.Decode(b, &vp); err != nil {
+		return err
+	}
+	v.Target = vp.Target
+	v.NewPower = int64(vp.NewPower)
+	return nil
+}
+
+// Validate checks that the payload has a target validator and a positive power.
+func (v *ValidatorUpdatePower) Validate() error {
+	if len(v.Target) == 0 {
+		return fmt.Errorf("%w: missing target validator", ErrMalformedPayload)
+	}
+	if v.NewPower <= 0 {
+		return fmt.Errorf("%w: power must be positive, not %d", ErrMalformedPayload, v.NewPower)
+	}
+	return nil
+}
+
 // Validator leave is used to signal that the sending validator is leaving the network
 type ValidatorLeave struct{}
 
@@ -2,9 +2,11 @@ package transactions_test
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/testdata"
 	"github.com/kwilteam/kwil-db/core/types/transactions"
 
 	"github.com/google/go-cmp/cmp"
@@ -180,6 +182,13 @@ func Test_Types(t *testing.T) {
 				Validator: []byte("asdfadsf"),
 			},
 		},
+		{
+			name: "validator_power",
+			obj: &transactions.ValidatorUpdatePower{
+				Target:   []byte("asdfadsf"),
+				NewPower: 1 << 40,
+			},
+		},
 		{
 			name: "validator_vote_approve",
 			obj: &transactions.ValidatorVoteIDs{
@@ -233,6 +242,8 @@ func Test_Types(t *testing.T) {
 				obj = &transactions.ValidatorLeave{}
 			case *transactions.ValidatorRemove:
 				obj = &transactions.ValidatorRemove{}
+			case *transactions.ValidatorUpdatePower:
+				obj = &transactions.ValidatorUpdatePower{}
 			case *transactions.ValidatorVoteIDs:
 				obj = &transactions.ValidatorVoteIDs{}
 			case *transactions.ValidatorVoteBodies:
@@ -258,16 +269,18 @@ func TestUnmarshalPayload(t *testing.T) {
 	// for each payload type, ensure UnmarshalPayload can recreate an instance
 	// of the expected type from just []byte and PayloadType. Contents and RLP
 	// quirks are not important, only that the type returned from
-	// UnmarshalPayload is correct.
+	// UnmarshalPayload is correct. Some payloads need minimal contents to be
+	// well-formed.
 	tests := []transactions.Payload{
-		&transactions.DropSchema{},
-		&transactions.Schema{},
-		&transactions.ActionCall{},
-		&transactions.ActionExecution{},
+		&transactions.DropSchema{DBID: "xdbid"},
+		&transactions.Schema{Name: "db"},
+		&transactions.ActionCall{DBID: "xdbid", Action: "act"},
+		&transactions.ActionExecution{DBID: "xdbid", Action: "act"},
 		&transactions.Transfer{},
 		&transactions.ValidatorApprove{},
 		&transactions.ValidatorJoin{},
 		&transactions.ValidatorRemove{},
+		&transactions.ValidatorUpdatePower{Target: []byte("validator"), NewPower: 5},
 		&transactions.ValidatorLeave{},
 		&transactions.ValidatorVoteIDs{},
 		&transactions.ValidatorVoteBodies{},
@@ -297,6 +310,118 @@ func TestUnmarshalPayload(t *testing.T) {
 	}
 }
 
+func TestUnmarshalPayload_Malformed(t *testing.T) {
+	arg := mustDetect(int64(1))
+	tests := []struct {
+		name string
+		in   transactions.Payload
+	}{
+		{"drop no dbid", &transactions.DropSchema{}},
+		{"schema no name", &transactions.Schema{Owner: []byte("user")}},
+		{"call no dbid", &transactions.ActionCall{Action: "act"}},
+		{"call no action", &transactions.ActionCall{DBID: "xdbid"}},
+		{"execute no dbid", &transactions.ActionExecution{Action: "act"}},
+		{"execute no action", &transactions.ActionExecution{DBID: "xdbid"}},
+		{"execute ragged arguments", &transactions.ActionExecution{
+			DBID:      "xdbid",
+			Action:    "act",
+			Arguments: [][]*transactions.EncodedValue{{arg, arg}, {arg}},
+		}},
+		{"power no target", &transactions.ValidatorUpdatePower{NewPower: 5}},
+		{"power negative", &transactions.ValidatorUpdatePower{Target: []byte("validator"), NewPower: -5}},
+		{"power zero", &transactions.ValidatorUpdatePower{Target: []byte("validator")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bts, err := tt.in.MarshalBinary()
+			require.NoError(t, err)
+
+			_, err = transactions.UnmarshalPayload(tt.in.Type(), bts)
+			require.ErrorIs(t, err, transactions.ErrMalformedPayload)
+
+			err = transactions.CheckPayload(tt.in.Type(), bts)
+			require.ErrorIs(t, err, transactions.ErrMalformedPayload)
+		})
+	}
+}
+
+func TestUnmarshalBinary_MalformedDecodes(t *testing.T) {
+	// Payloads in existing blocks must decode as they always have, so the
+	// well-formedness checks are not part of UnmarshalBinary.
+	tests := []transactions.Payload{
+		&transactions.DropSchema{},
+		&transactions.Schema{Owner: []byte("user")},
+		&transactions.ActionCall{DBID: "xdbid"},
+		&transactions.ActionExecution{DBID: "xdbid"},
+		&transactions.ValidatorUpdatePower{Target: []byte("validator")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Type().String(), func(t *testing.T) {
+			bts, err := tt.MarshalBinary()
+			require.NoError(t, err)
+
+			got := reflect.New(reflect.TypeOf(tt).Elem()).Interface().(transactions.Payload)
+			require.NoError(t, got.UnmarshalBinary(bts))
+			require.ErrorIs(t, transactions.ValidatePayload(got), transactions.ErrMalformedPayload)
+		})
+	}
+}
+
+// FuzzUnmarshalPayload checks that decoding arbitrary bytes as a payload never
+// panics, and that any payload that is decoded without error can be encoded
+// again.
+func FuzzUnmarshalPayload(f *testing.F) {
+	payloadTypes := []transactions.PayloadType{
+		transactions.PayloadTypeExecute,
+		transactions.PayloadTypeCallAction,
+		transactions.PayloadTypeDeploySchema,
+		transactions.PayloadTypeDropSchema,
+	}
+
+	seeds := []transactions.Payload{
+		&transactions.ActionExecution{
+			DBID:      "xdbid",
+			Action:    "act",
+			Arguments: [][]*transactions.EncodedValue{{mustDetect("a"), mustDetect(int64(1))}},
+		},
+		&transactions.ActionCall{
+			DBID:      "xdbid",
+			Action:    "act",
+			Arguments: []*transactions.EncodedValue{mustDetect([]string{"a", "b"})},
+		},
+		&transactions.Schema{
+			Name: "db",
+			Tables: []*transactions.Table{{
+				Name: "users",
+				Columns: []*transactions.Column{{
+					Name: "id",
+					Type: &transactions.DataType{Name: "int"},
+				}},
+			}},
+		},
+		&transactions.DropSchema{DBID: "xdbid"},
+	}
+	for _, seed := range seeds {
+		bts, err := seed.MarshalBinary()
+		require.NoError(f, err)
+		f.Add(bts)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, pt := range payloadTypes {
+			payload, err := transactions.UnmarshalPayload(pt, data)
+			if err != nil {
+				continue
+			}
+			if _, err = payload.MarshalBinary(); err != nil {
+				t.Errorf("failed to re-encode decoded %s payload: %v", pt, err)
+			}
+		}
+	})
+}
+
 func TestExtendedPayloadType(t *testing.T) {
 	noopPayload := transactions.PayloadType("noop")
 	assert.False(t, noopPayload.Valid())
@@ -375,3 +500,26 @@ func Test_EncodeValue(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffSchemas(t *testing.T) {
+	oldSchema := &transactions.Schema{}
+	oldSchema.FromTypes(testdata.TestSchema)
+
+	newSchema := &transactions.Schema{}
+	newSchema.FromTypes(testdata.TestSchema)
+	diff, err := transactions.DiffSchemas(oldSchema, newSchema)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	newSchema.Tables[0].Columns = append(newSchema.Tables[0].Columns, &transactions.Column{
+		Name: "new_col",
+		Type: &transactions.DataType{Name: types.TextType.Name},
+	})
+	diff, err = transactions.DiffSchemas(oldSchema, newSchema)
+	require.NoError(t, err)
+	require.Len(t, diff.ModifiedTables, 1)
+	assert.Equal(t, []string{"new_col"}, diff.ModifiedTables[0].AddedColumns)
+
+	_, err = transactions.DiffSchemas(oldSchema, nil)
+	assert.Error(t, err)
+}
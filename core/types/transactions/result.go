@@ -2,6 +2,7 @@ package transactions
 
 import (
 	"errors"
+	"fmt"
 	"math"
 )
 
@@ -23,8 +24,52 @@ var (
 	ErrInvalidNonce        = errors.New("invalid nonce")
 	ErrInvalidAmount       = errors.New("invalid amount")
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrTxExpired           = errors.New("transaction expired")
+	// ErrTxFailed is returned by TransactionResult.Error for any transaction
+	// that did not execute successfully.
+	ErrTxFailed = errors.New("transaction failed")
 )
 
+// txCodeErrors are the named errors for transaction result codes. The errors
+// for these codes returned by TransactionResult.Error match both ErrTxFailed
+// and the named error.
+var txCodeErrors = map[TxCode]error{
+	CodeWrongChain:          ErrWrongChain,
+	CodeInvalidNonce:        ErrInvalidNonce,
+	CodeInvalidAmount:       ErrInvalidAmount,
+	CodeInsufficientBalance: ErrInsufficientBalance,
+	CodeTxExpired:           ErrTxExpired,
+}
+
+// IsSuccess returns true if the transaction executed successfully, that is
+// if the result code is CodeOk.
+func (r *TransactionResult) IsSuccess() bool {
+	return r.Code == CodeOk.Uint32()
+}
+
+// Error returns nil if the transaction executed successfully. Otherwise it
+// returns an error wrapping ErrTxFailed, which describes the result code and
+// includes the log, which for a failed action execution (CodeUnknownError)
+// has the reason. For codes with named errors, such as CodeInvalidNonce and
+// ErrInvalidNonce, the error also wraps the named error.
+func (r *TransactionResult) Error() error {
+	if r.IsSuccess() {
+		return nil
+	}
+
+	code := TxCode(r.Code)
+	var err error
+	if named, ok := txCodeErrors[code]; ok {
+		err = fmt.Errorf("%w: %w", ErrTxFailed, named)
+	} else {
+		err = fmt.Errorf("%w: %v (code %d)", ErrTxFailed, code, r.Code)
+	}
+	if r.Log != "" {
+		err = fmt.Errorf("%w: %s", err, r.Log)
+	}
+	return err
+}
+
 type TxCode uint32
 
 const (
@@ -38,6 +83,7 @@ const (
 	CodeInsufficientFee     TxCode = 7
 	CodeInvalidAmount       TxCode = 8
 	CodeInvalidSender       TxCode = 9
+	CodeTxExpired           TxCode = 10
 
 	// engine-related error code
 	CodeInvalidSchema  TxCode = 100
@@ -71,6 +117,16 @@ func (c TxCode) String() string {
 		return "insufficient fee"
 	case CodeInvalidAmount:
 		return "invalid amount"
+	case CodeInvalidSender:
+		return "invalid sender"
+	case CodeTxExpired:
+		return "transaction expired"
+	case CodeInvalidSchema:
+		return "invalid schema"
+	case CodeDatasetMissing:
+		return "dataset missing"
+	case CodeDatasetExists:
+		return "dataset exists"
 	default:
 		return "unknown tx error"
 	}
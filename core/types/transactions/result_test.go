@@ -0,0 +1,57 @@
+package transactions_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionResult_Error(t *testing.T) {
+	tests := []struct {
+		code    transactions.TxCode
+		wantErr error // in addition to ErrTxFailed
+	}{
+		{transactions.CodeEncodingError, nil},
+		{transactions.CodeInvalidTxType, nil},
+		{transactions.CodeInvalidSignature, nil},
+		{transactions.CodeInvalidNonce, transactions.ErrInvalidNonce},
+		{transactions.CodeWrongChain, transactions.ErrWrongChain},
+		{transactions.CodeInsufficientBalance, transactions.ErrInsufficientBalance},
+		{transactions.CodeInsufficientFee, nil},
+		{transactions.CodeInvalidAmount, transactions.ErrInvalidAmount},
+		{transactions.CodeInvalidSender, nil},
+		{transactions.CodeTxExpired, transactions.ErrTxExpired},
+		{transactions.CodeInvalidSchema, nil},
+		{transactions.CodeDatasetMissing, nil},
+		{transactions.CodeDatasetExists, nil},
+		{transactions.CodeUnknownError, nil},
+	}
+
+	namedErrs := []error{transactions.ErrInvalidNonce, transactions.ErrWrongChain,
+		transactions.ErrInsufficientBalance, transactions.ErrInvalidAmount, transactions.ErrTxExpired}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			res := &transactions.TransactionResult{Code: tt.code.Uint32(), Log: "the log"}
+			assert.False(t, res.IsSuccess())
+
+			err := res.Error()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, transactions.ErrTxFailed)
+			assert.Contains(t, err.Error(), "the log")
+			for _, named := range namedErrs {
+				assert.Equal(t, named == tt.wantErr, errors.Is(err, named), named)
+			}
+		})
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		res := &transactions.TransactionResult{Code: transactions.CodeOk.Uint32()}
+		assert.True(t, res.IsSuccess())
+		assert.NoError(t, res.Error())
+	})
+}
@@ -1,11 +1,16 @@
 package transactions
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"slices"
 	"strings"
+	"sync/atomic"
 
 	"github.com/kwilteam/kwil-db/core/crypto"
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
@@ -34,6 +39,20 @@ Nonce: %d
 Kwil Chain ID: %s
 `
 
+// txMsgToSignTmplValidUntil is txMsgToSignTmplV0 with the ValidUntilHeight,
+// which is only used when it is set so that the message for a transaction
+// without an expiry is unchanged.
+const txMsgToSignTmplValidUntil = `%s
+
+PayloadType: %s
+PayloadDigest: %x
+Fee: %s
+Nonce: %d
+Valid Until Height: %d
+
+Kwil Chain ID: %s
+`
+
 // SignedMsgSerializationType is the type of serialization performed on a
 // transaction body(in signing and verification)
 // The main reason we need this is that this type could also to used as the
@@ -120,6 +139,10 @@ func CreateTransaction(contents Payload, chainID string, nonce uint64) (*Transac
 	}, nil
 }
 
+// Transaction is a signed transaction. Since the transaction caches its hash
+// once Hash is called, two otherwise identical transactions may not be equal
+// with reflect.DeepEqual (or testify's Equal). Compare transactions by their
+// Hash or MarshalBinary serialization instead.
 type Transaction struct {
 	// Signature is the signature of the transaction.
 	Signature *auth.Signature `json:"signature,omitempty"`
@@ -134,6 +157,11 @@ type Transaction struct {
 	// Sender is the user identifier, which is generally an address but may be
 	// a public key of the sender.
 	Sender types.HexBytes `json:"sender"`
+
+	// hash caches the result of Hash. It is reset by Sign, UnmarshalBinary,
+	// ReadFrom, and UnmarshalJSON, but not by direct modification of the other
+	// fields.
+	hash atomic.Pointer[[sha256.Size]byte]
 }
 
 // SerializeMsg produces the serialization of the transaction that is to be used
@@ -160,10 +188,58 @@ func (t *Transaction) Sign(signer auth.Signer) error {
 
 	t.Signature = signature
 	t.Sender = signer.Identity()
+	t.hash.Store(nil)
 
 	return nil
 }
 
+// ErrUnsupportedSignatureType is returned by Verify when the transaction's
+// signature type has no Authenticator defined in the core/crypto/auth package.
+var ErrUnsupportedSignatureType = errors.New("unsupported signature type")
+
+// Verify checks that the transaction's Signature is valid for the Sender over
+// the serialized Body. For EthPersonalSignAuth signatures, the Sender must be
+// the address recovered from the signature, and for Ed25519Auth it must be the
+// public key that verifies the signature.
+//
+// Only the signature types of the SDK-provided Signers are recognized. kwild
+// verifies transactions with the Authenticator registry in common/ident, which
+// may include types added by extensions.
+//
+// Verify is always strict about EthPersonalSignAuth signatures, and rejects a
+// high-S signature with an error wrapping crypto.ErrSignatureHighS. kwild
+// accepts such signatures until the "strict_sigs" hardfork activates, so a
+// transaction that fails only with that error may be valid on a chain where
+// the fork is not active, or may have been committed before it was.
+func (t *Transaction) Verify() error {
+	if t.Signature == nil {
+		return errors.New("transaction is not signed")
+	}
+	if t.Body == nil {
+		return errors.New("transaction has no body")
+	}
+
+	var authn auth.Authenticator
+	switch t.Signature.Type {
+	case auth.EthPersonalSignAuth:
+		authn = auth.EthSecp256k1Authenticator{}
+	case auth.Ed25519Auth:
+		authn = auth.Ed25519Authenticator{}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedSignatureType, t.Signature.Type)
+	}
+
+	msg, err := t.SerializeMsg()
+	if err != nil {
+		return err
+	}
+
+	// The eth authenticator normalizes the recovery ID in place, so give it a
+	// copy rather than modifying the transaction's signature.
+	sig := slices.Clone(t.Signature.Signature)
+	return authn.Verify(t.Sender, msg, sig)
+}
+
 // MarshalBinary produces the full binary serialization of the transaction,
 // which is the form used in p2p messaging and blockchain storage.
 func (t *Transaction) MarshalBinary() (serialize.SerializedData, error) {
@@ -171,9 +247,79 @@ func (t *Transaction) MarshalBinary() (serialize.SerializedData, error) {
 }
 
 func (t *Transaction) UnmarshalBinary(data serialize.SerializedData) error {
+	t.hash.Store(nil)
 	return serialize.Decode(data, t)
 }
 
+// UnmarshalJSON decodes a transaction from JSON, resetting the cached hash.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction // without the UnmarshalJSON method
+	t.hash.Store(nil)
+	return json.Unmarshal(data, (*transaction)(t))
+}
+
+// MaxTransactionSize is the largest serialized transaction that ReadFrom will
+// decode. The mempool's configured max_tx_bytes is usually much smaller.
+const MaxTransactionSize = 1 << 26 // 64 MiB
+
+// ReadFrom decodes the binary serialization of a transaction, as produced by
+// MarshalBinary, from a stream. The transaction does not need to be buffered
+// first, and no more than the bytes of the transaction are read from r. The
+// length prefixes of the body, payload, signature, and other fields are
+// checked against MaxTransactionSize before they are allocated. This
+// implements io.ReaderFrom, but unlike most implementations, it reads a single
+// transaction rather than the entire stream.
+func (t *Transaction) ReadFrom(r io.Reader) (int64, error) {
+	t.hash.Store(nil)
+	return serialize.DecodeFrom(r, t, MaxTransactionSize)
+}
+
+// Hash returns the hash of the transaction, which is the SHA-256 hash of its
+// full binary serialization. This is the same as the hash used by CometBFT to
+// index the transaction. The result is cached after the first call, so the
+// transaction should not be modified after it is hashed, except by Sign or one
+// of the unmarshal methods. Hash is safe for concurrent use.
+func (t *Transaction) Hash() (TxHash, error) {
+	if h := t.hash.Load(); h != nil {
+		return slices.Clone(h[:]), nil
+	}
+
+	bts, err := t.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.Sum256(bts)
+	t.hash.Store(&h)
+	return slices.Clone(h[:]), nil
+}
+
+// Clone returns a deep copy of the transaction. The copy shares no byte slices
+// or big.Int values with the original, so either may be modified without
+// affecting the other. The cached hash is not copied, so the clone's Hash is
+// computed from its own contents.
+func (t *Transaction) Clone() *Transaction {
+	tx := &Transaction{
+		Serialization: t.Serialization,
+		Sender:        slices.Clone(t.Sender),
+	}
+	if t.Signature != nil {
+		tx.Signature = &auth.Signature{
+			Signature: slices.Clone(t.Signature.Signature),
+			Type:      t.Signature.Type,
+		}
+	}
+	if t.Body != nil {
+		body := *t.Body
+		body.Payload = slices.Clone(t.Body.Payload)
+		if t.Body.Fee != nil {
+			body.Fee = new(big.Int).Set(t.Body.Fee)
+		}
+		tx.Body = &body
+	}
+	return tx
+}
+
 // TransactionBody is the body of a transaction that gets included in the
 // signature. This type implements json.Marshaler and json.Unmarshaler to ensure
 // that the Fee field is represented as a string in JSON rather than a number.
@@ -203,6 +349,18 @@ type TransactionBody struct {
 	// consensus engine and p2p systems as an opaque blob that must be
 	// unmarshalled with the chain ID in Kwil blockchain application.
 	ChainID string `json:"chain_id"`
+
+	// ValidUntilHeight is the last block height at which the transaction may
+	// be included in a block. Zero means the transaction does not expire. It
+	// is optional in the binary serialization, so a body without an expiry
+	// has the same serialization as before the field was added.
+	ValidUntilHeight uint64 `json:"valid_until_height,omitempty" rlp:"optional"`
+}
+
+// Expired reports whether the transaction body may no longer be included in a
+// block at the given height because of its ValidUntilHeight.
+func (t *TransactionBody) Expired(height int64) bool {
+	return t.ValidUntilHeight != 0 && height > 0 && uint64(height) > t.ValidUntilHeight
 }
 
 // MarshalJSON marshals to JSON but with Fee as a string.
@@ -217,6 +375,7 @@ func (t TransactionBody) MarshalJSON() ([]byte, error) {
 		Fee         string                   `json:"fee"`
 		Nonce       uint64                   `json:"nonce"`
 		ChainID     string                   `json:"chain_id"`
+		ValidUntil  uint64                   `json:"valid_until_height,omitempty"`
 	}{
 		Description: t.Description,
 		Payload:     t.Payload,
@@ -224,6 +383,7 @@ func (t TransactionBody) MarshalJSON() ([]byte, error) {
 		Fee:         t.Fee.String(), // *big.Int => string
 		Nonce:       t.Nonce,
 		ChainID:     t.ChainID,
+		ValidUntil:  t.ValidUntilHeight,
 	})
 }
 
@@ -257,6 +417,51 @@ func (t *TransactionBody) MarshalBinary() ([]byte, error) {
 	return serialize.Encode(t)
 }
 
+// CanonicalJSON returns a deterministic JSON encoding of the transaction body,
+// with keys in sorted order, no insignificant whitespace or HTML escaping, the
+// Fee as a base 10 string ("0" if nil), the Nonce as an integer, and the
+// Payload in standard base64. Any two bodies with the same field values have
+// the same canonical JSON, regardless of how they were constructed, and it may
+// be decoded with UnmarshalJSON.
+//
+// JSON is never signed or hashed by Kwil. The signed message is created by
+// SerializeMsg from the field values, and the transaction hash is computed from
+// the binary (RLP) serialization, which is authoritative. CanonicalJSON is for
+// clients that need a stable representation of the body, for instance to
+// compare or cache bodies constructed from JSON.
+func (t *TransactionBody) CanonicalJSON() ([]byte, error) {
+	fee := "0"
+	if t.Fee != nil {
+		fee = t.Fee.String()
+	}
+	// Fields are declared in order of their sorted JSON keys.
+	canon := struct {
+		ChainID     string                   `json:"chain_id"`
+		Description string                   `json:"desc"`
+		Fee         string                   `json:"fee"`
+		Nonce       uint64                   `json:"nonce"`
+		Payload     serialize.SerializedData `json:"payload"`
+		PayloadType PayloadType              `json:"type"`
+		ValidUntil  uint64                   `json:"valid_until_height,omitempty"`
+	}{
+		ChainID:     t.ChainID,
+		Description: t.Description,
+		Fee:         fee,
+		Nonce:       t.Nonce,
+		Payload:     t.Payload,
+		PayloadType: t.PayloadType,
+		ValidUntil:  t.ValidUntilHeight,
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(&canon); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
 // SerializeMsg prepares a message for signing or verification using a certain
 // message construction format. This is done since a Kwil transaction is foreign
 // to wallets, and it is signed as a message, not a transaction that is native
@@ -275,6 +480,17 @@ func (t *TransactionBody) SerializeMsg(mst SignedMsgSerializationType) ([]byte,
 		// NOTE: 'payload` is still in binary form(RLP encoded),
 		// we present its hash in the result message.
 		payloadDigest := crypto.Sha256(t.Payload)[:20]
+		if t.ValidUntilHeight != 0 {
+			msgStr := fmt.Sprintf(txMsgToSignTmplValidUntil,
+				t.Description,
+				t.PayloadType.String(),
+				payloadDigest,
+				t.Fee.String(),
+				t.Nonce,
+				t.ValidUntilHeight,
+				t.ChainID)
+			return []byte(msgStr), nil
+		}
 		msgStr := fmt.Sprintf(txMsgToSignTmplV0,
 			t.Description,
 			t.PayloadType.String(),
@@ -1,18 +1,24 @@
 package transactions_test
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
+	"slices"
+	"sync"
 	"testing"
+	"testing/iotest"
 
 	"github.com/kwilteam/kwil-db/core/crypto"
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
 	"github.com/kwilteam/kwil-db/core/types/serialize"
 	"github.com/kwilteam/kwil-db/core/types/transactions"
 
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -95,6 +101,66 @@ func Test_TransactionBodyMarshalJSON(t *testing.T) {
 	require.Equal(t, txB, txB3)
 }
 
+func TestTransactionBody_CanonicalJSON(t *testing.T) {
+	txB := &transactions.TransactionBody{
+		Description: "a <b> & c",
+		Payload:     []byte("payload"),
+		PayloadType: transactions.PayloadTypeExecute,
+		Fee:         big.NewInt(100),
+		Nonce:       12,
+		ChainID:     "chainIDXXX",
+	}
+
+	canon, err := txB.CanonicalJSON()
+	require.NoError(t, err)
+	const want = `{"chain_id":"chainIDXXX","desc":"a <b> & c","fee":"100","nonce":12,"payload":"cGF5bG9hZA==","type":"execute"}`
+	assert.Equal(t, want, string(canon))
+
+	t.Run("field order", func(t *testing.T) {
+		// The same body in JSON with keys in different orders and with
+		// whitespace has the same canonical JSON.
+		inputs := []string{
+			`{"type":"execute","nonce":12,"fee":"100","payload":"cGF5bG9hZA==","desc":"a <b> & c","chain_id":"chainIDXXX"}`,
+			`{ "nonce": 12, "chain_id": "chainIDXXX", "payload": "cGF5bG9hZA==",
+			   "fee": "100", "desc": "a \u003cb\u003e \u0026 c", "type": "execute" }`,
+		}
+		for _, in := range inputs {
+			var body transactions.TransactionBody
+			require.NoError(t, json.Unmarshal([]byte(in), &body))
+			got, err := body.CanonicalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, want, string(got))
+		}
+	})
+
+	t.Run("nil fee", func(t *testing.T) {
+		body := *txB
+		body.Fee = nil
+		got, err := body.CanonicalJSON()
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"fee":"0"`)
+	})
+
+	t.Run("same binary and signed message", func(t *testing.T) {
+		// The binary serialization, which is hashed, and the message that is
+		// signed are the same for a body decoded from its canonical JSON.
+		var body transactions.TransactionBody
+		require.NoError(t, json.Unmarshal(canon, &body))
+
+		wantBin, err := txB.MarshalBinary()
+		require.NoError(t, err)
+		gotBin, err := body.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, wantBin, gotBin)
+
+		wantMsg, err := txB.SerializeMsg(transactions.SignedMsgConcat)
+		require.NoError(t, err)
+		gotMsg, err := body.SerializeMsg(transactions.SignedMsgConcat)
+		require.NoError(t, err)
+		assert.Equal(t, wantMsg, gotMsg)
+	})
+}
+
 type actionExecutionV0 struct {
 	DBID      string
 	Action    string
@@ -331,3 +397,306 @@ abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ
 		})
 	}
 }
+
+func TestTransaction_Verify(t *testing.T) {
+	secpKey, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+	edKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+	otherEdKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+
+	newSignedTx := func(t *testing.T, signer auth.Signer) *transactions.Transaction {
+		tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+		require.NoError(t, err)
+		require.NoError(t, tx.Sign(signer))
+		return tx
+	}
+
+	signers := map[string]auth.Signer{
+		"eth personal sign": &auth.EthPersonalSigner{Key: *secpKey},
+		"ed25519":           &auth.Ed25519Signer{Ed25519PrivateKey: *edKey},
+	}
+
+	for name, signer := range signers {
+		t.Run(name, func(t *testing.T) {
+			t.Run("valid", func(t *testing.T) {
+				tx := newSignedTx(t, signer)
+				sig := append([]byte{}, tx.Signature.Signature...)
+				require.NoError(t, tx.Verify())
+				require.Equal(t, sig, tx.Signature.Signature) // not modified
+			})
+
+			t.Run("wrong sender", func(t *testing.T) {
+				tx := newSignedTx(t, signer)
+				tx.Sender = (&auth.Ed25519Signer{Ed25519PrivateKey: *otherEdKey}).Identity()
+				if signer.AuthType() == auth.EthPersonalSignAuth {
+					tx.Sender = tx.Sender[:20] // address length
+				}
+				require.Error(t, tx.Verify())
+			})
+
+			t.Run("tampered body", func(t *testing.T) {
+				tx := newSignedTx(t, signer)
+				tx.Body.Nonce++
+				require.Error(t, tx.Verify())
+			})
+		})
+	}
+
+	t.Run("high S", func(t *testing.T) {
+		tx := newSignedTx(t, signers["eth personal sign"])
+
+		// (R, N-S) with the flipped recovery ID is valid for kwild until
+		// the strict_sigs hardfork, but Verify always rejects it.
+		sig := tx.Signature.Signature
+		s := new(big.Int).SetBytes(sig[32:64])
+		new(big.Int).Sub(ethCrypto.S256().Params().N, s).FillBytes(sig[32:64])
+		sig[64] ^= 1
+		require.ErrorIs(t, tx.Verify(), crypto.ErrSignatureHighS)
+	})
+
+	t.Run("unsupported signature type", func(t *testing.T) {
+		tx := newSignedTx(t, signers["ed25519"])
+		tx.Signature.Type = "nope"
+		require.ErrorIs(t, tx.Verify(), transactions.ErrUnsupportedSignatureType)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+		require.NoError(t, err)
+		require.Error(t, tx.Verify())
+	})
+}
+
+func TestTransaction_Hash(t *testing.T) {
+	secpKey, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+	signer := &auth.EthPersonalSigner{Key: *secpKey}
+
+	tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+	require.NoError(t, err)
+
+	unsignedHash, err := tx.Hash()
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Sign(signer))
+
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, unsignedHash, hash) // Sign resets the cached hash
+
+	bts, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	wantHash := sha256.Sum256(bts)
+	require.Equal(t, transactions.TxHash(wantHash[:]), hash)
+
+	// Repeated and concurrent calls give the same hash.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, err := tx.Hash()
+			assert.NoError(t, err)
+			assert.Equal(t, hash, h)
+		}()
+	}
+	wg.Wait()
+
+	// Modifying the returned hash does not affect the cached one.
+	hash[0]++
+	hash2, err := tx.Hash()
+	require.NoError(t, err)
+	require.Equal(t, transactions.TxHash(wantHash[:]), hash2)
+
+	// A decoded transaction has the same hash.
+	var tx2 transactions.Transaction
+	require.NoError(t, tx2.UnmarshalBinary(bts))
+	hash3, err := tx2.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash2, hash3)
+
+	// Unmarshalling JSON into a transaction that was hashed resets the cache.
+	unsigned, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+	require.NoError(t, err)
+	unsignedJSON, err := json.Marshal(unsigned)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(unsignedJSON, &tx2))
+	hash4, err := tx2.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash3, hash4)
+	bts, err = tx2.MarshalBinary()
+	require.NoError(t, err)
+	wantHash = sha256.Sum256(bts)
+	require.Equal(t, transactions.TxHash(wantHash[:]), hash4)
+}
+
+func TestTransaction_Clone(t *testing.T) {
+	secpKey, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+	signer := &auth.EthPersonalSigner{Key: *secpKey}
+
+	tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+	require.NoError(t, err)
+	tx.Body.Fee = big.NewInt(100)
+	require.NoError(t, tx.Sign(signer))
+
+	origBts, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	origHash, err := tx.Hash()
+	require.NoError(t, err)
+
+	cloneHash, err := tx.Clone().Hash()
+	require.NoError(t, err)
+	require.Equal(t, origHash, cloneHash)
+
+	clone := tx.Clone()
+	cloneBts, err := clone.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, origBts, cloneBts)
+
+	// Mutate every reference type in the clone.
+	clone.Sender[0]++
+	clone.Signature.Signature[0]++
+	clone.Signature.Type = "other"
+	clone.Body.Payload[0]++
+	clone.Body.Fee.Add(clone.Body.Fee, big.NewInt(1))
+	clone.Body.Nonce++
+
+	bts, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, origBts, bts)
+	assert.Equal(t, big.NewInt(100), tx.Body.Fee)
+	assert.NoError(t, tx.Verify())
+
+	// The clone's hash reflects its changes, not the original's cached hash.
+	cloneHash, err = clone.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, origHash, cloneHash)
+	cloneBts, err = clone.MarshalBinary()
+	require.NoError(t, err)
+	wantHash := sha256.Sum256(cloneBts)
+	assert.Equal(t, transactions.TxHash(wantHash[:]), cloneHash)
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, origHash, hash)
+
+	// An unsigned transaction with no fee can also be cloned.
+	tx, err = transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+	require.NoError(t, err)
+	clone = tx.Clone()
+	assert.Nil(t, clone.Signature)
+	assert.Equal(t, tx.Body, clone.Body)
+}
+
+func BenchmarkTransaction_Hash(b *testing.B) {
+	tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", 1)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tx.Hash(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTransaction_ReadFrom(t *testing.T) {
+	edKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer := &auth.Ed25519Signer{Ed25519PrivateKey: *edKey}
+
+	newTx := func(nonce uint64) *transactions.Transaction {
+		tx, err := transactions.CreateTransaction(&transactions.DropSchema{DBID: "xdbid"}, "chainIDXXX", nonce)
+		require.NoError(t, err)
+		require.NoError(t, tx.Sign(signer))
+		return tx
+	}
+	tx1, tx2 := newTx(1), newTx(2)
+	bts1, err := tx1.MarshalBinary()
+	require.NoError(t, err)
+	bts2, err := tx2.MarshalBinary()
+	require.NoError(t, err)
+
+	t.Run("valid stream", func(t *testing.T) {
+		// Two transactions back to back, read without over-reading.
+		r := iotest.OneByteReader(bytes.NewReader(append(slices.Clone(bts1), bts2...)))
+
+		for _, want := range [][]byte{bts1, bts2} {
+			var tx transactions.Transaction
+			n, err := tx.ReadFrom(r)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(want)), n)
+
+			got, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			assert.NoError(t, tx.Verify())
+		}
+	})
+
+	t.Run("truncated stream", func(t *testing.T) {
+		var tx transactions.Transaction
+		_, err := tx.ReadFrom(bytes.NewReader(bts1[:len(bts1)/2]))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		_, err = tx.ReadFrom(bytes.NewReader(nil))
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("oversized length prefix", func(t *testing.T) {
+		// RLP type prefix, then a list header claiming 2 GiB of contents.
+		bts := []byte{0x00, 0x01, 0xfb, 0x7f, 0xff, 0xff, 0xff, 0xc0}
+		var tx transactions.Transaction
+		_, err := tx.ReadFrom(bytes.NewReader(bts))
+		assert.ErrorIs(t, err, serialize.ErrTooLarge)
+	})
+}
+
+func TestTransactionBody_ValidUntilHeight(t *testing.T) {
+	edKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer := &auth.Ed25519Signer{Ed25519PrivateKey: *edKey}
+
+	noExpiry, err := transactions.NewTransaction(&transactions.DropSchema{DBID: "xdbid"},
+		transactions.WithChainID("chainIDXXX"), transactions.WithNonce(1))
+	require.NoError(t, err)
+	tx, err := transactions.NewTransaction(&transactions.DropSchema{DBID: "xdbid"},
+		transactions.WithChainID("chainIDXXX"), transactions.WithNonce(1),
+		transactions.WithValidUntilHeight(100), transactions.WithSigner(signer))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), tx.Body.ValidUntilHeight)
+
+	// The height is in the signed message only when it is set.
+	msg, err := tx.SerializeMsg()
+	require.NoError(t, err)
+	assert.Contains(t, string(msg), "Valid Until Height: 100\n")
+	msg, err = noExpiry.SerializeMsg()
+	require.NoError(t, err)
+	assert.NotContains(t, string(msg), "Valid Until Height")
+
+	// Binary round trip, and a signature that covers the height.
+	bts, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	var tx2 transactions.Transaction
+	require.NoError(t, tx2.UnmarshalBinary(bts))
+	assert.Equal(t, uint64(100), tx2.Body.ValidUntilHeight)
+	require.NoError(t, tx2.Verify())
+	tx2.Body.ValidUntilHeight++
+	require.Error(t, tx2.Verify())
+
+	// JSON round trip, with the field omitted when zero.
+	jsonBody, err := json.Marshal(tx.Body)
+	require.NoError(t, err)
+	var body transactions.TransactionBody
+	require.NoError(t, json.Unmarshal(jsonBody, &body))
+	assert.Equal(t, uint64(100), body.ValidUntilHeight)
+	jsonBody, err = json.Marshal(noExpiry.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(jsonBody), "valid_until_height")
+
+	assert.False(t, tx.Body.Expired(100))
+	assert.True(t, tx.Body.Expired(101))
+	assert.False(t, noExpiry.Body.Expired(1e9))
+}
@@ -1,8 +1,13 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
 )
 
 // TODO: doc it all
@@ -20,6 +25,13 @@ const (
 	AccountStatusPending
 )
 
+// AccountStates is both the confirmed (latest) and pending states of an
+// account, such as to show a pending spend.
+type AccountStates struct {
+	Latest  *Account `json:"latest"`
+	Pending *Account `json:"pending"`
+}
+
 // ChainInfo describes the current status of a Kwil blockchain.
 type ChainInfo struct {
 	ChainID     string `json:"chain_id"`
@@ -39,6 +51,60 @@ type JoinRequest struct {
 	Approved  []bool   `json:"approved"`   // slice of bools indicating if the corresponding validator approved
 }
 
+// ApprovalStatus returns the number of board members that have approved the
+// join request, and the size of the board.
+func (jr *JoinRequest) ApprovalStatus() (approved, total int) {
+	for _, a := range jr.Approved {
+		if a {
+			approved++
+		}
+	}
+	return approved, len(jr.Board)
+}
+
+// ApprovalsNeeded returns the number of approvals needed for at least the given
+// fraction of the board, such as 2.0/3, to have approved.
+func (jr *JoinRequest) ApprovalsNeeded(fraction float64) int {
+	// The small tolerance keeps float error (e.g. 0.7*10 = 7.000000000000001)
+	// from requiring an extra approval.
+	return int(math.Ceil(fraction*float64(len(jr.Board)) - 1e-9))
+}
+
+// Threshold reports whether at least the given fraction of the board has
+// approved the join request. This counts board members, not voting power,
+// which is what the network uses to resolve the request, so it is only an
+// indication for display.
+func (jr *JoinRequest) Threshold(fraction float64) bool {
+	approved, _ := jr.ApprovalStatus()
+	return approved >= jr.ApprovalsNeeded(fraction)
+}
+
+// Validate checks that the join request is well formed: it has a candidate, a
+// positive power and expiry height, and an approval for each board member, with
+// no board member listed twice.
+func (jr *JoinRequest) Validate() error {
+	if len(jr.Candidate) == 0 {
+		return errors.New("missing candidate")
+	}
+	if jr.Power <= 0 {
+		return fmt.Errorf("invalid power %d", jr.Power)
+	}
+	if jr.ExpiresAt <= 0 {
+		return fmt.Errorf("invalid expiry height %d", jr.ExpiresAt)
+	}
+	if len(jr.Board) != len(jr.Approved) {
+		return fmt.Errorf("board has %d members but %d approvals", len(jr.Board), len(jr.Approved))
+	}
+	seen := make(map[string]bool, len(jr.Board))
+	for _, member := range jr.Board {
+		if seen[string(member)] {
+			return fmt.Errorf("duplicate board member %x", member)
+		}
+		seen[string(member)] = true
+	}
+	return nil
+}
+
 type Validator struct {
 	PubKey []byte `json:"pubkey"`
 	Power  int64  `json:"power"`
@@ -62,6 +128,21 @@ type DatasetIdentifier struct {
 	DBID  string   `json:"dbid"`
 }
 
+// DB ID is a convention. This is likely to change:
+// https://github.com/kwilteam/kwil-db/issues/332
+
+// GenerateDBID computes the DBID of a dataset with the given name and owner
+// identifier. The DBID is "x" followed by the hex encoded SHA-224 hash of the
+// lower cased name concatenated with the owner identifier bytes. It is the
+// same on clients and nodes, so clients may use this to know a dataset's DBID
+// before it is deployed.
+func GenerateDBID(name string, owner []byte) string {
+	h := sha256.New224()
+	h.Write([]byte(strings.ToLower(name)))
+	h.Write(owner)
+	return "x" + hex.EncodeToString(h.Sum(nil))
+}
+
 // VotableEvent is an event that can be voted.
 // It contains an event type and a body.
 // An ID can be generated from the event type and body.
@@ -0,0 +1,109 @@
+package types_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDBID(t *testing.T) {
+	ethAddr, _ := hex.DecodeString("c89d42189f0450c2b2c3c61f58ec5d628176a1e7")
+
+	tests := []struct {
+		name  string
+		owner []byte
+		want  string
+	}{
+		{"mydb", ethAddr, "x5e1209d6ac6e2e99a61a40015f02403ef738274f69c0acf80d5d54e5"},
+		{"MyDB", ethAddr, "x5e1209d6ac6e2e99a61a40015f02403ef738274f69c0acf80d5d54e5"}, // case insensitive
+		{"name", []byte("owner"), "x415df866f94736b6f7869738c3e4e7df2a08b99108c3aee16564f342"},
+		{"", nil, "xd14a028c2a3a2bc9476102bb288234c415a2b01f828ea62ac5b3e42f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, types.GenerateDBID(tt.name, tt.owner))
+
+			schema := &types.Schema{Name: tt.name, Owner: tt.owner}
+			assert.Equal(t, tt.want, schema.DBID())
+		})
+	}
+}
+
+func TestJoinRequest_Validate(t *testing.T) {
+	valid := func() *types.JoinRequest {
+		return &types.JoinRequest{
+			Candidate: []byte("candidate"),
+			Power:     1,
+			ExpiresAt: 100,
+			Board:     [][]byte{[]byte("a"), []byte("b"), []byte("c")},
+			Approved:  []bool{true, false, false},
+		}
+	}
+	assert.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(jr *types.JoinRequest)
+	}{
+		{"no candidate", func(jr *types.JoinRequest) { jr.Candidate = nil }},
+		{"zero power", func(jr *types.JoinRequest) { jr.Power = 0 }},
+		{"no expiry", func(jr *types.JoinRequest) { jr.ExpiresAt = 0 }},
+		{"fewer approvals", func(jr *types.JoinRequest) { jr.Approved = jr.Approved[:2] }},
+		{"more approvals", func(jr *types.JoinRequest) { jr.Approved = append(jr.Approved, true) }},
+		{"duplicate board member", func(jr *types.JoinRequest) { jr.Board[2] = []byte("a") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jr := valid()
+			tt.modify(jr)
+			assert.Error(t, jr.Validate())
+		})
+	}
+}
+
+func TestJoinRequest_ApprovalStatus(t *testing.T) {
+	newJoin := func(approved ...bool) *types.JoinRequest {
+		jr := &types.JoinRequest{Approved: approved}
+		for i := range approved {
+			jr.Board = append(jr.Board, []byte{byte(i)})
+		}
+		return jr
+	}
+
+	tests := []struct {
+		name         string
+		jr           *types.JoinRequest
+		wantApproved int
+		wantTotal    int
+		wantNeeded   int // for 2/3
+		wantMet      bool
+	}{
+		{"empty board", newJoin(), 0, 0, 0, true},
+		{"1 of 1", newJoin(true), 1, 1, 1, true},
+		{"0 of 1", newJoin(false), 0, 1, 1, false},
+		{"1 of 3", newJoin(true, false, false), 1, 3, 2, false},
+		{"2 of 3", newJoin(true, false, true), 2, 3, 2, true},
+		{"3 of 6", newJoin(true, true, true, false, false, false), 3, 6, 4, false},
+		{"4 of 6", newJoin(true, true, false, true, false, true), 4, 6, 4, true},
+		{"4 of 7", newJoin(true, true, true, true, false, false, false), 4, 7, 5, false},
+		{"5 of 7", newJoin(true, true, true, true, true, false, false), 5, 7, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approved, total := tt.jr.ApprovalStatus()
+			assert.Equal(t, tt.wantApproved, approved)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantNeeded, tt.jr.ApprovalsNeeded(2.0/3))
+			assert.Equal(t, tt.wantMet, tt.jr.Threshold(2.0/3))
+		})
+	}
+
+	// Float error does not require an extra approval.
+	jr := newJoin(true, true, true, true, true, true, true, false, false, false)
+	assert.Equal(t, 7, jr.ApprovalsNeeded(0.7))
+	assert.True(t, jr.Threshold(0.7))
+}
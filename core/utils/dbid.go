@@ -1,17 +1,11 @@
 package utils
 
-import (
-	"crypto/sha256"
-	"encoding/hex"
-	"strings"
-)
-
-// DB ID is a convention. This is likely to change:
-// https://github.com/kwilteam/kwil-db/issues/332
+import "github.com/kwilteam/kwil-db/core/types"
 
+// GenerateDBID computes the DBID of a dataset from its name and the identifier
+// of its owner.
+//
+// Deprecated: Use types.GenerateDBID.
 func GenerateDBID(name string, ownerID []byte) string {
-	h := sha256.New224()
-	h.Write([]byte(strings.ToLower(name)))
-	h.Write(ownerID)
-	return "x" + hex.EncodeToString(h.Sum(nil))
+	return types.GenerateDBID(name, ownerID)
 }
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
 )
@@ -24,8 +25,12 @@ var (
 	ErrAuthenticatorNotFound = errors.New("authenticator not found")
 )
 
-// registeredAuthenticators is the Authenticator registry used by kwild.
-var registeredAuthenticators = make(map[string]auth.Authenticator)
+// registeredAuthenticators is the Authenticator registry used by kwild. The
+// default Authenticators are registered by the common/ident package.
+var (
+	registryMtx              sync.RWMutex
+	registeredAuthenticators = make(map[string]auth.Authenticator)
+)
 
 // ModOperation is the type used to enumerate authenticator modifications.
 type ModOperation int8
@@ -38,9 +43,13 @@ const (
 )
 
 // RegisterAuthenticator registers, removes, or updates an authenticator with
-// the Kwil network.
+// the Kwil network. It is safe for concurrent use, so an extension may register
+// a new signature scheme at any time, but this is normally done in an init
+// function.
 func RegisterAuthenticator(mod ModOperation, name string, auth auth.Authenticator) error {
 	name = strings.ToLower(name)
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
 	if _, ok := registeredAuthenticators[name]; ok {
 		switch mod {
 		case ModAdd:
@@ -63,9 +72,11 @@ func RegisterAuthenticator(mod ModOperation, name string, auth auth.Authenticato
 	return nil
 }
 
-// getAuthenticator returns an authenticator by the name it was registered with
+// GetAuthenticator returns an authenticator by the name it was registered with.
 func GetAuthenticator(name string) (auth.Authenticator, error) {
 	name = strings.ToLower(name)
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
 	auth, ok := registeredAuthenticators[name]
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", ErrAuthenticatorNotFound, name)
@@ -0,0 +1,73 @@
+package auth_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	coreauth "github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/kwilteam/kwil-db/extensions/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// customAuthenticator verifies ed25519 signatures under a different name, and
+// counts the calls to Verify.
+type customAuthenticator struct {
+	coreauth.Ed25519Authenticator
+	mtx      sync.Mutex
+	verifies int
+}
+
+func (c *customAuthenticator) Verify(publicKey, msg, signature []byte) error {
+	c.mtx.Lock()
+	c.verifies++
+	c.mtx.Unlock()
+	return c.Ed25519Authenticator.Verify(publicKey, msg, signature)
+}
+
+func Test_RegisterAuthenticator(t *testing.T) {
+	const name = "custom_ed25519"
+	authn := &customAuthenticator{}
+
+	require.NoError(t, auth.RegisterAuthenticator(auth.ModAdd, name, authn))
+	defer auth.RegisterAuthenticator(auth.ModRemove, name, nil)
+
+	// names are case insensitive, and may not be added twice
+	err := auth.RegisterAuthenticator(auth.ModAdd, "Custom_ED25519", authn)
+	require.ErrorIs(t, err, auth.ErrAuthenticatorExists)
+
+	key, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer := &coreauth.Ed25519Signer{Ed25519PrivateKey: *key}
+	msg := []byte("a message")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+
+	got, err := auth.GetAuthenticator(name)
+	require.NoError(t, err)
+	require.NoError(t, got.Verify(signer.Identity(), msg, sig.Signature))
+	require.Error(t, got.Verify(signer.Identity(), []byte("another message"), sig.Signature))
+	assert.Equal(t, 2, authn.verifies)
+
+	// concurrent lookups while the registry is modified
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := auth.GetAuthenticator(name)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, auth.RegisterAuthenticator(auth.ModUpdate, name, authn))
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, auth.RegisterAuthenticator(auth.ModRemove, name, nil))
+	_, err = auth.GetAuthenticator(name)
+	require.ErrorIs(t, err, auth.ErrAuthenticatorNotFound)
+}
@@ -2,6 +2,11 @@ package consensus
 
 import (
 	"github.com/kwilteam/kwil-db/common/chain/forks"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+	authExt "github.com/kwilteam/kwil-db/extensions/auth"
+	"github.com/kwilteam/kwil-db/extensions/resolutions"
+	"github.com/kwilteam/kwil-db/internal/voting"
 )
 
 // Register the canonical (non-extension) hard forks that are baked into kwild.
@@ -15,4 +20,48 @@ func init() {
 		// NOTE: canonical forks can define any of the standardized updates, but
 		// this one does not.
 	})
+
+	RegisterHardfork(&Hardfork{
+		// "strict_sigs" replaces the default secp256k1 authenticator, which
+		// accepts high-S signatures for compatibility with existing chains,
+		// with one that rejects them. It needs no named field in the Forks
+		// struct since the change is made entirely with an AuthUpdate.
+		Name: forks.ForkStrictSigs,
+
+		AuthUpdates: []*AuthMod{
+			{
+				Name:      auth.EthPersonalSignAuth,
+				Operation: authExt.ModUpdate,
+				Authn:     auth.EthSecp256k1Authenticator{},
+			},
+		},
+	})
+
+	RegisterHardfork(&Hardfork{
+		// "tx_expiry" allows transactions with a ValidUntilHeight. The change
+		// is checked in kwild with forks.IsActivated, so there are no
+		// standard updates.
+		Name: forks.ForkTxExpiry,
+	})
+
+	RegisterHardfork(&Hardfork{
+		// "validator_power" adds the validator_power payload and the
+		// resolution that applies a validator power update once enough
+		// validators have voted for it.
+		Name: forks.ForkValidatorPower,
+
+		TxPayloads: []Payload{
+			{
+				Type:  transactions.PayloadTypeValidatorPower,
+				Route: &voting.ValidatorPowerRoute{},
+			},
+		},
+		ResolutionUpdates: []*ResolutionMod{
+			{
+				Name:      voting.ValidatorPowerEventType,
+				Operation: resolutions.ModAdd,
+				Config:    &voting.ValidatorPowerResolution,
+			},
+		},
+	})
 }
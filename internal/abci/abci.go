@@ -130,22 +130,14 @@ func NewAbciApp(ctx context.Context, cfg *AbciConfig, snapshotter SnapshotModule
 	networkParams, err := meta.LoadParams(ctx, tx)
 	if err == meta.ErrParamsNotFound {
 		// we need to store the genesis network params
-		err = meta.StoreParams(ctx, tx, &common.NetworkParameters{
-			MaxBlockSize:     app.consensusParams.Block.MaxBytes,
-			JoinExpiry:       app.consensusParams.Validator.JoinExpiry,
-			VoteExpiry:       app.consensusParams.Votes.VoteExpiry,
-			DisabledGasCosts: app.consensusParams.WithoutGasCosts,
-		})
+		networkParams = app.consensusParams.NetworkParameters()
+		if err = chain.ValidateNetworkParameters(networkParams); err != nil {
+			return nil, fmt.Errorf("invalid genesis network params: %w", err)
+		}
+		err = meta.StoreParams(ctx, tx, networkParams)
 		if err != nil {
 			return nil, fmt.Errorf("failed to store network params: %w", err)
 		}
-
-		networkParams = &common.NetworkParameters{
-			MaxBlockSize:     app.consensusParams.Block.MaxBytes,
-			JoinExpiry:       app.consensusParams.Validator.JoinExpiry,
-			VoteExpiry:       app.consensusParams.Votes.VoteExpiry,
-			DisabledGasCosts: app.consensusParams.WithoutGasCosts,
-		}
 	} else if err != nil {
 		return nil, fmt.Errorf("failed to load network params: %w", err)
 	} else {
@@ -240,6 +232,12 @@ func (a *AbciApp) ChainID() string {
 	return a.cfg.ChainID
 }
 
+// txExpiryEnabled returns true if transactions may set a ValidUntilHeight in a
+// block at the given height, which is when the "tx_expiry" hardfork is active.
+func (a *AbciApp) txExpiryEnabled(height int64) bool {
+	return height >= 0 && a.forks.IsActivated(forks.ForkTxExpiry, uint64(height))
+}
+
 // Activations consults chain config for the names of hard forks that activate
 // at the given block height, and retrieves the associated changes from the
 // consensus package that contains the canonical and extended fork definitions.
@@ -333,6 +331,15 @@ func (a *AbciApp) CheckTx(ctx context.Context, incoming *abciTypes.RequestCheckT
 			logger.Debug("failed to verify transaction", zap.Error(err))
 			return &abciTypes.ResponseCheckTx{Code: code.Uint32(), Log: err.Error()}, nil
 		}
+
+		// Reject malformed payloads from the mempool. This is not done in
+		// block execution, where such payloads have always been decoded.
+		err = transactions.CheckPayload(tx.Body.PayloadType, tx.Body.Payload)
+		if err != nil {
+			code = codeEncodingError
+			logger.Debug("malformed transaction payload", zap.Error(err))
+			return &abciTypes.ResponseCheckTx{Code: code.Uint32(), Log: err.Error()}, nil
+		}
 	} else {
 		logger.Info("Recheck", zap.String("sender", hex.EncodeToString(tx.Sender)), zap.Uint64("nonce", tx.Body.Nonce), zap.String("payloadType", tx.Body.PayloadType.String()))
 	}
@@ -343,6 +350,26 @@ func (a *AbciApp) CheckTx(ctx context.Context, incoming *abciTypes.RequestCheckT
 	}
 	defer readTx.Rollback(ctx) // always rollback since we are read-only
 
+	// Reject (or evict on recheck) a transaction that can no longer be
+	// included in the next block, or that sets an expiry before it is allowed.
+	if tx.Body.ValidUntilHeight != 0 {
+		height, _, err := meta.GetChainState(ctx, readTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain state: %w", err)
+		}
+		if !a.txExpiryEnabled(height + 1) {
+			code = codeInvalidTxType
+			logger.Info("transaction expiry not yet enabled", zap.Uint64("validUntil", tx.Body.ValidUntilHeight))
+			return &abciTypes.ResponseCheckTx{Code: code.Uint32(), Log: "transaction expiry (valid until height) is not enabled"}, nil
+		}
+		if tx.Body.Expired(height + 1) {
+			code = codeTxExpired
+			logger.Info("transaction expired", zap.Uint64("validUntil", tx.Body.ValidUntilHeight),
+				zap.Int64("nextHeight", height+1))
+			return &abciTypes.ResponseCheckTx{Code: code.Uint32(), Log: transactions.ErrTxExpired.Error()}, nil
+		}
+	}
+
 	err = a.txApp.ApplyMempool(ctx, readTx, tx)
 	if err != nil {
 		if errors.Is(err, transactions.ErrInvalidNonce) {
@@ -517,7 +544,7 @@ func (a *AbciApp) FinalizeBlock(ctx context.Context, req *abciTypes.RequestFinal
 	}
 
 	// store any changes to the network params
-	err = meta.StoreDiff(ctx, a.consensusTx, oldNetworkParams, networkParams)
+	err = meta.StoreDiff(ctx, a.consensusTx, oldNetworkParams, networkParams, req.Height, req.Time.Unix())
 	if err != nil {
 		return nil, fmt.Errorf("failed to store network params diff: %w", err)
 	}
@@ -820,6 +847,12 @@ func (a *AbciApp) ApplySnapshotChunk(ctx context.Context, req *abciTypes.Request
 	}
 
 	if dbRestored {
+		// The snapshot may be from a node with an older chain metadata store,
+		// which must be upgraded before the next block is executed.
+		if err = a.upgradeChainStore(ctx); err != nil {
+			return &abciTypes.ResponseApplySnapshotChunk{Result: abciTypes.ResponseApplySnapshotChunk_ABORT}, err
+		}
+
 		readTx, err := a.db.BeginReadTx(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to begin read tx: %w", err)
@@ -837,6 +870,22 @@ func (a *AbciApp) ApplySnapshotChunk(ctx context.Context, req *abciTypes.Request
 	return &abciTypes.ResponseApplySnapshotChunk{Result: abciTypes.ResponseApplySnapshotChunk_ACCEPT, RefetchChunks: nil}, nil
 }
 
+// upgradeChainStore upgrades the chain metadata store in a database restored
+// from a snapshot to the current schema version.
+func (a *AbciApp) upgradeChainStore(ctx context.Context) error {
+	tx, err := a.db.BeginOuterTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin outer tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = meta.InitializeMetaStore(ctx, tx); err != nil {
+		return fmt.Errorf("failed to upgrade chain metadata store: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
 // ListSnapshots is on the state sync connection
 func (a *AbciApp) ListSnapshots(ctx context.Context, req *abciTypes.RequestListSnapshots) (*abciTypes.ResponseListSnapshots, error) {
 	if a.snapshotter == nil {
@@ -1029,6 +1078,15 @@ func (a *AbciApp) prepareBlockTransactions(ctx context.Context, txs [][]byte, lo
 			continue // mempool recheck should have removed this
 		}
 
+		if tx.Body.ValidUntilHeight != 0 && !a.txExpiryEnabled(height) {
+			log.Warn("Dropping tx with an expiry from block proposal before it is enabled", zap.Uint64("validUntil", tx.Body.ValidUntilHeight))
+			continue // should not have passed CheckTx to get into our mempool
+		}
+		if tx.Body.Expired(height) {
+			log.Warn("Dropping expired tx from block proposal", zap.Uint64("validUntil", tx.Body.ValidUntilHeight))
+			continue // mempool recheck should have removed this
+		}
+
 		// Drop transactions from unfunded accounts in gasEnabled mode
 		if a.cfg.GasEnabled {
 			balance, nonce, err := a.txApp.AccountInfo(ctx, readTx, tx.Sender, false)
@@ -1155,7 +1213,7 @@ func (a *AbciApp) PrepareProposal(ctx context.Context, req *abciTypes.RequestPre
 	}, nil
 }
 
-func (a *AbciApp) validateProposalTransactions(ctx context.Context, txns [][]byte, proposer []byte) error {
+func (a *AbciApp) validateProposalTransactions(ctx context.Context, txns [][]byte, proposer []byte, height int64) error {
 	logger := a.log.With(zap.String("stage", "ABCI ProcessProposal"))
 	grouped, err := groupTxsBySender(txns)
 	if err != nil {
@@ -1192,6 +1250,18 @@ func (a *AbciApp) validateProposalTransactions(ctx context.Context, txns [][]byt
 				return fmt.Errorf("protected transaction with mismatched chain ID")
 			}
 
+			if tx.Body.ValidUntilHeight != 0 {
+				// Before activation, nodes that predate the field would fail to
+				// decode this transaction and reject the block, so do the same.
+				if !a.txExpiryEnabled(height) {
+					return fmt.Errorf("transaction expiry is not enabled at height %d", height)
+				}
+				if tx.Body.Expired(height) {
+					return fmt.Errorf("transaction valid until height %d included at height %d",
+						tx.Body.ValidUntilHeight, height)
+				}
+			}
+
 			// if it is a vote body payload, then only the proposer can propose it
 			// this is a hard consensus rule for block building, and is protected by
 			// the mempool.
@@ -1216,6 +1286,8 @@ func (a *AbciApp) validateProposalTransactions(ctx context.Context, txns [][]byt
 // 2. nonce is less than the last committed nonce for the account
 // 3. duplicates or gaps in the nonces
 // 4. transaction size is greater than the max_tx_bytes
+// 5. a transaction has expired (its ValidUntilHeight is before this height), or
+// sets a ValidUntilHeight before the "tx_expiry" hardfork
 // else accept the proposed block.
 func (a *AbciApp) ProcessProposal(ctx context.Context, req *abciTypes.RequestProcessProposal) (*abciTypes.ResponseProcessProposal, error) {
 	logger := a.log.With(zap.String("stage", "ABCI ProcessProposal"),
@@ -1245,7 +1317,7 @@ func (a *AbciApp) ProcessProposal(ctx context.Context, req *abciTypes.RequestPro
 		return &abciTypes.ResponseProcessProposal{Status: abciTypes.ResponseProcessProposal_REJECT}, nil
 	}
 
-	if err := a.validateProposalTransactions(ctx, req.Txs, proposerPubKey); err != nil {
+	if err := a.validateProposalTransactions(ctx, req.Txs, proposerPubKey, req.Height); err != nil {
 		logger.Warn("rejecting block proposal", zap.Error(err))
 		return &abciTypes.ResponseProcessProposal{Status: abciTypes.ResponseProcessProposal_REJECT}, nil
 	}
@@ -1254,7 +1326,16 @@ func (a *AbciApp) ProcessProposal(ctx context.Context, req *abciTypes.RequestPro
 	return &abciTypes.ResponseProcessProposal{Status: abciTypes.ResponseProcessProposal_ACCEPT}, nil
 }
 
+// ParamHistoryQueryPath is the ABCI query path for the recorded changes to a
+// network parameter. The query data is the parameter name, such as
+// "max_block_size", and the response value is a JSON array of meta.ParamChange.
+const ParamHistoryQueryPath = "/params/history"
+
 func (a *AbciApp) Query(ctx context.Context, req *abciTypes.RequestQuery) (*abciTypes.ResponseQuery, error) {
+	if req.Path == ParamHistoryQueryPath {
+		return a.queryParamHistory(ctx, string(req.Data))
+	}
+
 	if req.Path == statesync.ABCISnapshotQueryPath { // "/snapshot/height"
 		if a.snapshotter == nil {
 			return &abciTypes.ResponseQuery{}, nil
@@ -1286,6 +1367,30 @@ func (a *AbciApp) Query(ctx context.Context, req *abciTypes.RequestQuery) (*abci
 	return &abciTypes.ResponseQuery{}, nil
 }
 
+// queryParamHistory responds to a ParamHistoryQueryPath query. Errors are
+// returned in the response, since they are usually from a bad query.
+func (a *AbciApp) queryParamHistory(ctx context.Context, param string) (*abciTypes.ResponseQuery, error) {
+	readTx, err := a.db.BeginReadTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer readTx.Rollback(ctx)
+
+	history, err := meta.GetParamHistory(ctx, readTx, param)
+	if err != nil {
+		return &abciTypes.ResponseQuery{Code: 1, Log: err.Error()}, nil
+	}
+	if history == nil {
+		history = []*meta.ParamChange{}
+	}
+
+	bts, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	return &abciTypes.ResponseQuery{Value: bts}, nil
+}
+
 type EventBroadcaster func(ctx context.Context, db sql.DB, proposer []byte) error
 
 func (a *AbciApp) SetEventBroadcaster(fn EventBroadcaster) {
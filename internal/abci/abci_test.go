@@ -3,10 +3,14 @@ package abci
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"math/big"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/kwilteam/kwil-db/common"
+	"github.com/kwilteam/kwil-db/common/chain/forks"
 	"github.com/kwilteam/kwil-db/common/sql"
 	"github.com/kwilteam/kwil-db/core/crypto"
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
@@ -14,8 +18,12 @@ import (
 	"github.com/kwilteam/kwil-db/core/types/transactions"
 
 	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/internal/statesync"
 	"github.com/kwilteam/kwil-db/internal/txapp"
+
+	abciTypes "github.com/cometbft/cometbft/abci/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func marshalTx(t *testing.T, tx *transactions.Transaction) []byte {
@@ -47,14 +55,19 @@ func cloneTx(tx *transactions.Transaction) *transactions.Transaction {
 }
 
 func newTxBts(t *testing.T, nonce uint64, signer auth.Signer) []byte {
+	return newTxBtsValidUntil(t, nonce, 0, signer)
+}
+
+func newTxBtsValidUntil(t *testing.T, nonce, validUntil uint64, signer auth.Signer) []byte {
 	tx := &transactions.Transaction{
 		Signature:     &auth.Signature{},
 		Serialization: transactions.SignedMsgConcat,
 		Body: &transactions.TransactionBody{
-			Description: "test",
-			Payload:     []byte(`random payload`),
-			Fee:         big.NewInt(0),
-			Nonce:       nonce,
+			Description:      "test",
+			Payload:          []byte(`random payload`),
+			Fee:              big.NewInt(0),
+			Nonce:            nonce,
+			ValidUntilHeight: validUntil,
 		},
 		Sender: signer.Identity(),
 	}
@@ -376,7 +389,7 @@ func Test_ProcessProposal_TxValidation(t *testing.T) {
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := abciApp.validateProposalTransactions(ctx, tc.txs, nil)
+			err := abciApp.validateProposalTransactions(ctx, tc.txs, nil, 1)
 			if tc.err {
 				assert.Error(t, err, "expected error due to %s", tc.name)
 			} else {
@@ -386,6 +399,91 @@ func Test_ProcessProposal_TxValidation(t *testing.T) {
 	}
 }
 
+func Test_ValidUntilHeight(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewStdOut(log.DebugLevel)
+	abciApp := &AbciApp{
+		txApp: &mockTxApp{},
+		db:    &mockDB{},
+		log:   logger,
+		forks: *forks.NewForks(map[string]*uint64{forks.ForkTxExpiry: ptrUint64(1)}),
+	}
+
+	key, _ := crypto.GenerateSecp256k1Key()
+	signer := &auth.EthPersonalSigner{Key: *key}
+
+	tx := newTxBtsValidUntil(t, 1, 10, signer)
+	var decoded transactions.Transaction
+	assert.NoError(t, decoded.UnmarshalBinary(tx))
+	assert.Equal(t, uint64(10), decoded.Body.ValidUntilHeight)
+
+	// Not allowed before the tx_expiry fork activates.
+	assert.Error(t, abciApp.validateProposalTransactions(ctx, [][]byte{tx}, nil, 0))
+	assert.Empty(t, abciApp.prepareBlockTransactions(ctx, [][]byte{tx}, &logger, 1e6, []byte("proposer"), 0))
+
+	// Included at or before the valid until height.
+	for _, height := range []int64{1, 10} {
+		assert.NoError(t, abciApp.validateProposalTransactions(ctx, [][]byte{tx}, nil, height))
+		got := abciApp.prepareBlockTransactions(ctx, [][]byte{tx}, &logger, 1e6, []byte("proposer"), height)
+		assert.Equal(t, [][]byte{tx}, got)
+	}
+
+	// Expired after it.
+	assert.Error(t, abciApp.validateProposalTransactions(ctx, [][]byte{tx}, nil, 11))
+	got := abciApp.prepareBlockTransactions(ctx, [][]byte{tx}, &logger, 1e6, []byte("proposer"), 11)
+	assert.Empty(t, got)
+
+	// No expiry.
+	tx = newTxBts(t, 1, signer)
+	assert.NoError(t, abciApp.validateProposalTransactions(ctx, [][]byte{tx}, nil, 1e6))
+}
+
+func Test_CheckTx_ValidUntilHeight(t *testing.T) {
+	ctx := context.Background()
+	key, _ := crypto.GenerateSecp256k1Key()
+	signer := &auth.EthPersonalSigner{Key: *key}
+
+	tx := newTxBtsValidUntil(t, 1, 10, signer)
+	noExpiryTx := newTxBts(t, 1, signer)
+
+	testcases := []struct {
+		name       string
+		forkHeight *uint64 // of tx_expiry
+		height     int64   // of the last block, so CheckTx is for height+1
+		tx         []byte
+		code       transactions.TxCode
+	}{
+		{"no expiry, fork disabled", nil, 5, noExpiryTx, codeOk},
+		{"fork disabled", nil, 5, tx, codeInvalidTxType},
+		{"before fork", ptrUint64(8), 5, tx, codeInvalidTxType},
+		{"fork active", ptrUint64(6), 5, tx, codeOk},
+		{"last valid height", ptrUint64(0), 9, tx, codeOk},
+		{"expired", ptrUint64(0), 10, tx, codeTxExpired},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			abciApp := &AbciApp{
+				txApp: &mockTxApp{},
+				db:    &mockChainStateDB{height: tc.height},
+				log:   log.NewNoOp(),
+				forks: *forks.NewForks(map[string]*uint64{forks.ForkTxExpiry: tc.forkHeight}),
+
+				txCache: make(map[string]bool),
+			}
+			for _, checkType := range []abciTypes.CheckTxType{abciTypes.CheckTxType_New, abciTypes.CheckTxType_Recheck} {
+				res, err := abciApp.CheckTx(ctx, &abciTypes.RequestCheckTx{Tx: tc.tx, Type: checkType})
+				require.NoError(t, err)
+				assert.Equal(t, tc.code.Uint32(), res.Code, res.Log)
+			}
+		})
+	}
+}
+
+func ptrUint64(v uint64) *uint64 {
+	return &v
+}
+
 type mockTxApp struct{}
 
 func (m *mockTxApp) MarkBroadcasted(ctx context.Context, ids []types.UUID) error {
@@ -480,3 +578,154 @@ func (m *mockTx) BeginTx(ctx context.Context) (sql.Tx, error) {
 func (m *mockTx) Precommit(ctx context.Context) ([]byte, error) {
 	return nil, nil
 }
+
+// mockChainStateDB is a mockDB with a chain state at the given height.
+type mockChainStateDB struct {
+	mockDB
+	height int64
+}
+
+func (m *mockChainStateDB) BeginReadTx(ctx context.Context) (sql.Tx, error) {
+	return &mockChainStateTx{height: m.height}, nil
+}
+
+type mockChainStateTx struct {
+	mockTx
+	height int64
+}
+
+func (m *mockChainStateTx) Execute(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error) {
+	return &sql.ResultSet{
+		Columns: []string{"height", "app_hash"},
+		Rows:    [][]any{{m.height, []byte{}}},
+	}, nil
+}
+
+// Test_ApplySnapshotChunk_UpgradesChainStore tests that the chain metadata
+// store of a database restored from a snapshot taken before the v2 chain store
+// is upgraded before the restore is accepted.
+func Test_ApplySnapshotChunk_UpgradesChainStore(t *testing.T) {
+	db := &mockRestoreDB{tx: &mockRestoreTx{version: 1}}
+	app := &AbciApp{
+		txApp:       &mockTxApp{},
+		db:          db,
+		statesyncer: &mockStateSyncer{restored: true},
+		log:         log.NewNoOp(),
+	}
+
+	res, err := app.ApplySnapshotChunk(context.Background(), &abciTypes.RequestApplySnapshotChunk{})
+	require.NoError(t, err)
+	require.Equal(t, abciTypes.ResponseApplySnapshotChunk_ACCEPT, res.Result)
+
+	require.True(t, db.tx.committed)
+	require.True(t, slices.ContainsFunc(db.tx.stmts, func(stmt string) bool {
+		return strings.Contains(stmt, "consensus_params_history")
+	}), "params history table not created")
+	require.Equal(t, int64(2), db.tx.version)
+}
+
+type mockStateSyncer struct {
+	restored bool
+}
+
+func (m *mockStateSyncer) OfferSnapshot(ctx context.Context, snapshot *statesync.Snapshot) error {
+	return nil
+}
+
+func (m *mockStateSyncer) ApplySnapshotChunk(ctx context.Context, chunk []byte, index uint32) (bool, error) {
+	return m.restored, nil
+}
+
+// mockRestoreDB is a mockDB whose outer tx records the statements executed in
+// it, and reports the chain store version.
+type mockRestoreDB struct {
+	mockDB
+	tx *mockRestoreTx
+}
+
+func (m *mockRestoreDB) BeginOuterTx(ctx context.Context) (sql.OuterTx, error) {
+	return m.tx, nil
+}
+
+type mockRestoreTx struct {
+	mockTx
+	version   int64
+	stmts     []string
+	committed bool
+}
+
+func (m *mockRestoreTx) Execute(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error) {
+	m.stmts = append(m.stmts, stmt)
+	switch {
+	case strings.HasPrefix(stmt, "SELECT version FROM"):
+		return &sql.ResultSet{
+			Columns: []string{"version"},
+			Rows:    [][]any{{m.version}},
+		}, nil
+	case strings.HasPrefix(stmt, "UPDATE") && strings.Contains(stmt, "_kwil_version"):
+		m.version = args[0].(int64)
+	}
+	return &sql.ResultSet{}, nil
+}
+
+func (m *mockRestoreTx) BeginTx(ctx context.Context) (sql.Tx, error) {
+	return m, nil
+}
+
+func (m *mockRestoreTx) Commit(ctx context.Context) error {
+	m.committed = true
+	return nil
+}
+
+func Test_Query_ParamHistory(t *testing.T) {
+	le := func(v uint64) []byte {
+		return binary.LittleEndian.AppendUint64(nil, v)
+	}
+	app := &AbciApp{
+		db: &mockParamHistoryDB{rows: [][]any{
+			{int64(10), le(1000), le(2000), int64(1700000000)},
+		}},
+		log: log.NewNoOp(),
+	}
+
+	res, err := app.Query(context.Background(), &abciTypes.RequestQuery{
+		Path: ParamHistoryQueryPath,
+		Data: []byte("max_block_size"),
+	})
+	require.NoError(t, err)
+	require.Zero(t, res.Code, res.Log)
+	require.JSONEq(t, `[{"height":10,"param":"max_block_size","old_value":1000,"new_value":2000,"timestamp":1700000000}]`, string(res.Value))
+
+	// a malformed value is reported in the response
+	app.db = &mockParamHistoryDB{rows: [][]any{
+		{int64(10), []byte{1}, le(2000), int64(1700000000)},
+	}}
+	res, err = app.Query(context.Background(), &abciTypes.RequestQuery{
+		Path: ParamHistoryQueryPath,
+		Data: []byte("max_block_size"),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, res.Code)
+}
+
+// mockParamHistoryDB is a mockDB whose read txs return the given rows.
+type mockParamHistoryDB struct {
+	mockDB
+	rows [][]any
+}
+
+func (m *mockParamHistoryDB) BeginReadTx(ctx context.Context) (sql.Tx, error) {
+	return &mockParamHistoryTx{rows: m.rows}, nil
+}
+
+type mockParamHistoryTx struct {
+	mockTx
+	rows [][]any
+}
+
+func (m *mockParamHistoryTx) Execute(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error) {
+	return &sql.ResultSet{
+		Columns: []string{"height", "old_value", "new_value", "timestamp"},
+		Rows:    m.rows,
+	}, nil
+}
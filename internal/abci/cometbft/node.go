@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/kwilteam/kwil-db/core/log"
 	"github.com/kwilteam/kwil-db/internal/abci/cometbft/privval"
@@ -222,6 +223,25 @@ func (n *CometBftNode) Stop() error {
 	return n.Node.Stop()
 }
 
+// SetPersistentPeers replaces the node's persistent peers, and dials any of
+// them that are not already connected. The peers are given like the
+// persistent_peers setting, as a comma separated list of nodeID@host:port
+// addresses. Connected peers that are no longer listed stay connected, but are
+// not redialed if they disconnect.
+func (n *CometBftNode) SetPersistentPeers(peers string) error {
+	var addrs []string
+	for _, addr := range strings.Split(peers, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	sw := n.Node.Switch()
+	if err := sw.AddPersistentPeers(addrs); err != nil {
+		return err
+	}
+	return sw.DialPeersAsync(addrs)
+}
+
 // IsCatchup returns true if the node is operating in catchup / blocksync
 // mode.  If the node is caught up with the network, it returns false.
 func (n *CometBftNode) IsCatchup() bool {
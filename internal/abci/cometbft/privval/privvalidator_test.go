@@ -10,6 +10,7 @@ import (
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cometbft/cometbft/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const defaultChainID = "test-chain"
@@ -186,6 +187,37 @@ func Test_PrivValidatorVote(t *testing.T) {
 	}
 }
 
+// Test_SignVoteVerifies checks that the votes signed by SignVote verify with
+// CometBFT, which rebuilds the sign bytes with the same types.VoteSignBytes and
+// types.VoteExtensionSignBytes used to sign them.
+func Test_SignVoteVerifies(t *testing.T) {
+	privKeyBts, err := hex.DecodeString(defaultPrivateKey)
+	require.NoError(t, err)
+	privVal, err := NewValidatorSigner(privKeyBts, newMockStore())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	for i, stp := range []cmtproto.SignedMsgType{cmtproto.PrevoteType, cmtproto.PrecommitType} {
+		vote := testVote(step(stp), height(int64(i+1)))
+		vote.ValidatorAddress = pubKey.Address()
+		if stp == cmtproto.PrecommitType {
+			vote.Extension = []byte("extension")
+		}
+		require.NoError(t, privVal.SignVote(defaultChainID, vote))
+
+		cmtVote, err := types.VoteFromProto(vote)
+		require.NoError(t, err)
+		require.NoError(t, cmtVote.Verify(defaultChainID, pubKey))
+		if stp == cmtproto.PrecommitType {
+			require.NoError(t, cmtVote.VerifyExtension(defaultChainID, pubKey))
+		}
+
+		// the signature does not verify for a different chain
+		require.Error(t, cmtVote.Verify("other-chain", pubKey))
+	}
+}
+
 func Test_Proposals(t *testing.T) {
 	type testCase struct {
 		// name is the name of the test case.
@@ -4,8 +4,10 @@
 package meta
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"slices"
 
@@ -17,7 +19,7 @@ import (
 const (
 	chainSchemaName = `kwild_chain`
 
-	chainStoreVersion = 1
+	chainStoreVersion = 2
 
 	initChainTable = `CREATE TABLE IF NOT EXISTS ` + chainSchemaName + `.chain (
 		height INT8 NOT NULL,
@@ -28,6 +30,15 @@ const (
 		param_value BYTEA
 	)`
 
+	initParamsHistoryTable = `CREATE TABLE IF NOT EXISTS ` + chainSchemaName + `.consensus_params_history (
+		height INT8 NOT NULL,
+		param_name TEXT NOT NULL,
+		old_value BYTEA,
+		new_value BYTEA,
+		timestamp INT8 NOT NULL,
+		PRIMARY KEY (height, param_name)
+	)`
+
 	insertChainState = `INSERT INTO ` + chainSchemaName + `.chain ` +
 		`VALUES ($1, $2);`
 
@@ -41,6 +52,15 @@ const (
 		`ON CONFLICT (param_name) DO UPDATE SET param_value = $2;`
 
 	getParams = `SELECT param_name, param_value FROM ` + chainSchemaName + `.consensus_params;`
+
+	// If a param is changed more than once at a height, the original old
+	// value is kept.
+	insertParamChange = `INSERT INTO ` + chainSchemaName + `.consensus_params_history ` +
+		`VALUES ($1, $2, $3, $4, $5) ` +
+		`ON CONFLICT (height, param_name) DO UPDATE SET new_value = $4, timestamp = $5;`
+
+	getParamHistory = `SELECT height, old_value, new_value, timestamp FROM ` + chainSchemaName + `.consensus_params_history ` +
+		`WHERE param_name = $1 ORDER BY height;`
 )
 
 func initTables(ctx context.Context, tx sql.DB) error {
@@ -56,6 +76,10 @@ func InitializeMetaStore(ctx context.Context, db sql.DB) error {
 			_, err := db.Execute(ctx, initConsensusParamsTable)
 			return err
 		},
+		2: func(ctx context.Context, db sql.DB) error {
+			_, err := db.Execute(ctx, initParamsHistoryTable)
+			return err
+		},
 	}
 
 	return versioning.Upgrade(ctx, db, chainSchemaName, upgradeFns, chainStoreVersion)
@@ -157,9 +181,11 @@ func StoreParams(ctx context.Context, db sql.TxMaker, params *common.NetworkPara
 	return tx.Commit(ctx)
 }
 
-// StoreDiff stores the difference between two sets of consensus params.
-// If the parameters are equal, no action is taken.
-func StoreDiff(ctx context.Context, db sql.TxMaker, original, new *common.NetworkParameters) error {
+// StoreDiff stores the difference between two sets of consensus params, and
+// records each change in the param history with the block height and time
+// (unix seconds) at which it was made. See GetParamHistory. If the parameters
+// are equal, no action is taken.
+func StoreDiff(ctx context.Context, db sql.TxMaker, original, new *common.NetworkParameters, height, timestamp int64) error {
 	diff := diff(original, new)
 	if len(diff) == 0 {
 		return nil
@@ -171,17 +197,97 @@ func StoreDiff(ctx context.Context, db sql.TxMaker, original, new *common.Networ
 	}
 	defer tx.Rollback(ctx)
 
-	for param, value := range diff {
+	// Write the params in a fixed order so the statements are reproducible.
+	// The names are the fixed keys of encodeParams, and a byte-wise sort does
+	// not depend on the map iteration order or the locale. The order does not
+	// affect the stored rows, which have one row per param and height.
+	params := make([]string, 0, len(diff))
+	for param := range diff {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+
+	oldValues := encodeParams(original)
+	for _, param := range params {
+		value := diff[param]
 		_, err = tx.Execute(ctx, upsertParam, param, value)
 		if err != nil {
 			return err
 		}
+
+		_, err = tx.Execute(ctx, insertParamChange, height, param, oldValues[param], value, timestamp)
+		if err != nil {
+			return err
+		}
 	}
 
 	return tx.Commit(ctx)
 }
 
-var ErrParamsNotFound = fmt.Errorf("params not found")
+// ParamChange is a change to a consensus param recorded by StoreDiff. The
+// values are int64, except for the disabled_gas_costs param, which is a bool.
+type ParamChange struct {
+	Height    int64  `json:"height"`
+	Param     string `json:"param"`
+	OldValue  any    `json:"old_value"`
+	NewValue  any    `json:"new_value"`
+	Timestamp int64  `json:"timestamp"` // block time, unix seconds
+}
+
+// GetParamHistory returns the recorded changes to a consensus param, such as
+// "max_block_size", in order of height.
+func GetParamHistory(ctx context.Context, db sql.Executor, paramName string) ([]*ParamChange, error) {
+	res, err := db.Execute(ctx, getParamHistory, paramName)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*ParamChange, len(res.Rows))
+	for i, row := range res.Rows {
+		if len(row) != 4 {
+			return nil, fmt.Errorf("expected four columns, got %d", len(row))
+		}
+
+		height, ok := sql.Int64(row[0])
+		if !ok {
+			return nil, fmt.Errorf("invalid type for height (%T)", row[0])
+		}
+		timestamp, ok := sql.Int64(row[3])
+		if !ok {
+			return nil, fmt.Errorf("invalid type for timestamp (%T)", row[3])
+		}
+
+		change := &ParamChange{
+			Height:    height,
+			Param:     paramName,
+			Timestamp: timestamp,
+		}
+		for j, v := range []*any{&change.OldValue, &change.NewValue} {
+			value, ok := row[j+1].([]byte)
+			if !ok {
+				return nil, fmt.Errorf("expected bytes for param value, got %T", row[j+1])
+			}
+			*v, err = decodeParam(paramName, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		changes[i] = change
+	}
+
+	return changes, nil
+}
+
+// ErrParamsNotFound is returned by LoadParams when no params are stored, as
+// before the first block. The other errors are returned when the stored params
+// are not as expected, such as after a failed or missing upgrade.
+var (
+	ErrParamsNotFound     = errors.New("params not found")
+	ErrParamCountMismatch = errors.New("wrong number of params")
+	ErrParamColumnShape   = errors.New("unexpected params columns")
+	ErrUnknownParam       = errors.New("unknown param")
+	ErrInvalidParamValue  = errors.New("invalid param value")
+)
 
 // LoadParams loads the consensus params from the store.
 func LoadParams(ctx context.Context, db sql.Executor) (*common.NetworkParameters, error) {
@@ -195,36 +301,39 @@ func LoadParams(ctx context.Context, db sql.Executor) (*common.NetworkParameters
 	}
 
 	if len(res.Rows) != 4 {
-		return nil, fmt.Errorf("expected four rows, got %d", len(res.Rows))
+		return nil, fmt.Errorf("%w: expected four rows, got %d", ErrParamCountMismatch, len(res.Rows))
 	}
 
 	params := &common.NetworkParameters{}
 	for _, row := range res.Rows {
 		if len(row) != 2 {
-			return nil, fmt.Errorf("expected two columns, got %d", len(row))
+			return nil, fmt.Errorf("%w: expected two columns, got %d", ErrParamColumnShape, len(row))
 		}
 
 		param, ok := row[0].(string)
 		if !ok {
-			return nil, fmt.Errorf("expected string for param name, got %T", row[0])
+			return nil, fmt.Errorf("%w: expected string for param name, got %T", ErrParamColumnShape, row[0])
 		}
 
 		value, ok := row[1].([]byte)
 		if !ok {
-			return nil, fmt.Errorf("expected bytes for param value, got %T", row[1])
+			return nil, fmt.Errorf("%w: expected bytes for param value, got %T", ErrParamColumnShape, row[1])
+		}
+
+		v, err := decodeParam(param, value)
+		if err != nil {
+			return nil, err
 		}
 
 		switch param {
 		case maxBlockSizeKey:
-			params.MaxBlockSize = int64(binary.LittleEndian.Uint64(value))
+			params.MaxBlockSize = v.(int64)
 		case joinExpiryKey:
-			params.JoinExpiry = int64(binary.LittleEndian.Uint64(value))
+			params.JoinExpiry = v.(int64)
 		case voteExpiryKey:
-			params.VoteExpiry = int64(binary.LittleEndian.Uint64(value))
+			params.VoteExpiry = v.(int64)
 		case disabledGasKey:
-			params.DisabledGasCosts = value[0] == 1
-		default:
-			return nil, fmt.Errorf("internal bug: unknown param name: %s", param)
+			params.DisabledGasCosts = v.(bool)
 		}
 	}
 
@@ -233,34 +342,49 @@ func LoadParams(ctx context.Context, db sql.Executor) (*common.NetworkParameters
 
 // diff returns the difference between two sets of consensus params.
 func diff(original, new *common.NetworkParameters) map[string][]byte {
+	oldValues, newValues := encodeParams(original), encodeParams(new)
 	d := make(map[string][]byte)
-	if original.MaxBlockSize != new.MaxBlockSize {
-		buf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(buf, uint64(new.MaxBlockSize))
-		d[maxBlockSizeKey] = buf
+	for param, value := range newValues {
+		if !bytes.Equal(oldValues[param], value) {
+			d[param] = value
+		}
 	}
+	return d
+}
 
-	if original.JoinExpiry != new.JoinExpiry {
-		buf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(buf, uint64(new.JoinExpiry))
-		d[joinExpiryKey] = buf
+// encodeParams encodes each of the consensus params as it is stored.
+func encodeParams(params *common.NetworkParameters) map[string][]byte {
+	encodeInt := func(v int64) []byte {
+		return binary.LittleEndian.AppendUint64(nil, uint64(v))
 	}
-
-	if original.VoteExpiry != new.VoteExpiry {
-		buf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(buf, uint64(new.VoteExpiry))
-		d[voteExpiryKey] = buf
+	disabledGas := []byte{0}
+	if params.DisabledGasCosts {
+		disabledGas[0] = 1
+	}
+	return map[string][]byte{
+		maxBlockSizeKey: encodeInt(params.MaxBlockSize),
+		joinExpiryKey:   encodeInt(params.JoinExpiry),
+		voteExpiryKey:   encodeInt(params.VoteExpiry),
+		disabledGasKey:  disabledGas,
 	}
+}
 
-	if original.DisabledGasCosts != new.DisabledGasCosts {
-		buf := make([]byte, 1)
-		if new.DisabledGasCosts {
-			buf[0] = 1
+// decodeParam decodes a stored consensus param value.
+func decodeParam(param string, value []byte) (any, error) {
+	switch param {
+	case maxBlockSizeKey, joinExpiryKey, voteExpiryKey:
+		if len(value) != 8 {
+			return nil, fmt.Errorf("%w: expected 8 bytes for %s, got %d", ErrInvalidParamValue, param, len(value))
+		}
+		return int64(binary.LittleEndian.Uint64(value)), nil
+	case disabledGasKey:
+		if len(value) != 1 {
+			return nil, fmt.Errorf("%w: expected 1 byte for %s, got %d", ErrInvalidParamValue, param, len(value))
 		}
-		d[disabledGasKey] = buf
+		return value[0] == 1, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownParam, param)
 	}
-
-	return d
 }
 
 const (
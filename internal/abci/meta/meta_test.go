@@ -2,11 +2,14 @@ package meta_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/kwilteam/kwil-db/common"
+	"github.com/kwilteam/kwil-db/common/sql"
 	"github.com/kwilteam/kwil-db/internal/abci/meta"
 	"github.com/kwilteam/kwil-db/internal/sql/pg"
+	"github.com/kwilteam/kwil-db/internal/sql/versioning"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,7 +66,7 @@ func Test_NetworkParams(t *testing.T) {
 	param2.JoinExpiry = 200
 	param2.DisabledGasCosts = false
 
-	err = meta.StoreDiff(ctx, tx, param, param2)
+	err = meta.StoreDiff(ctx, tx, param, param2, 10, 1700000000)
 	require.NoError(t, err)
 
 	param3, err := meta.LoadParams(ctx, tx)
@@ -71,3 +74,299 @@ func Test_NetworkParams(t *testing.T) {
 
 	require.EqualValues(t, param2, param3)
 }
+
+func Test_ParamHistory(t *testing.T) {
+	cfg := &pg.DBConfig{
+		PoolConfig: pg.PoolConfig{
+			ConnConfig: pg.ConnConfig{
+				Host:   "127.0.0.1",
+				Port:   "5432",
+				User:   "kwild",
+				Pass:   "kwild", // would be ignored if pg_hba.conf set with trust
+				DBName: "kwil_test_db",
+			},
+			MaxConns: 11,
+		},
+	}
+
+	ctx := context.Background()
+
+	db, err := pg.NewDB(ctx, cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.BeginOuterTx(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) // always rollback to reset the test
+
+	err = meta.InitializeMetaStore(ctx, tx)
+	require.NoError(t, err)
+
+	param := &common.NetworkParameters{
+		MaxBlockSize:     1000,
+		JoinExpiry:       100,
+		VoteExpiry:       100,
+		DisabledGasCosts: true,
+	}
+
+	err = meta.StoreParams(ctx, tx, param)
+	require.NoError(t, err)
+
+	// no changes yet
+	history, err := meta.GetParamHistory(ctx, tx, "max_block_size")
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	param2 := *param
+	param2.MaxBlockSize = 2000
+	err = meta.StoreDiff(ctx, tx, param, &param2, 10, 1700000000)
+	require.NoError(t, err)
+
+	param3 := param2
+	param3.MaxBlockSize = 3000
+	param3.DisabledGasCosts = false
+	err = meta.StoreDiff(ctx, tx, &param2, &param3, 20, 1700000100)
+	require.NoError(t, err)
+
+	history, err = meta.GetParamHistory(ctx, tx, "max_block_size")
+	require.NoError(t, err)
+	require.Equal(t, []*meta.ParamChange{
+		{Height: 10, Param: "max_block_size", OldValue: int64(1000), NewValue: int64(2000), Timestamp: 1700000000},
+		{Height: 20, Param: "max_block_size", OldValue: int64(2000), NewValue: int64(3000), Timestamp: 1700000100},
+	}, history)
+
+	history, err = meta.GetParamHistory(ctx, tx, "disabled_gas_costs")
+	require.NoError(t, err)
+	require.Equal(t, []*meta.ParamChange{
+		{Height: 20, Param: "disabled_gas_costs", OldValue: true, NewValue: false, Timestamp: 1700000100},
+	}, history)
+
+	// unchanged params have no history
+	history, err = meta.GetParamHistory(ctx, tx, "vote_expiry")
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func Test_UpgradeV1ToV2(t *testing.T) {
+	cfg := &pg.DBConfig{
+		PoolConfig: pg.PoolConfig{
+			ConnConfig: pg.ConnConfig{
+				Host:   "127.0.0.1",
+				Port:   "5432",
+				User:   "kwild",
+				Pass:   "kwild", // would be ignored if pg_hba.conf set with trust
+				DBName: "kwil_test_db",
+			},
+			MaxConns: 11,
+		},
+	}
+
+	ctx := context.Background()
+
+	db, err := pg.NewDB(ctx, cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.BeginOuterTx(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) // always rollback to reset the test
+
+	// create the version 1 store, as an existing node would have it
+	v1Fns := map[int64]versioning.UpgradeFunc{
+		0: func(ctx context.Context, db sql.DB) error {
+			_, err := db.Execute(ctx, `CREATE TABLE IF NOT EXISTS kwild_chain.chain (
+				height INT8 NOT NULL,
+				app_hash BYTEA
+			);`)
+			return err
+		},
+		1: func(ctx context.Context, db sql.DB) error {
+			_, err := db.Execute(ctx, `CREATE TABLE IF NOT EXISTS kwild_chain.consensus_params (
+				param_name TEXT PRIMARY KEY,
+				param_value BYTEA
+			)`)
+			return err
+		},
+	}
+	err = versioning.Upgrade(ctx, tx, "kwild_chain", v1Fns, 1)
+	require.NoError(t, err)
+
+	err = meta.SetChainState(ctx, tx, 5, []byte("app hash"))
+	require.NoError(t, err)
+
+	param := &common.NetworkParameters{
+		MaxBlockSize:     1000,
+		JoinExpiry:       100,
+		VoteExpiry:       100,
+		DisabledGasCosts: true,
+	}
+	err = meta.StoreParams(ctx, tx, param)
+	require.NoError(t, err)
+
+	// upgrade to the latest version
+	err = meta.InitializeMetaStore(ctx, tx)
+	require.NoError(t, err)
+
+	res, err := tx.Execute(ctx, `SELECT version FROM kwild_chain._kwil_version WHERE name = 'version'`)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	version, ok := sql.Int64(res.Rows[0][0])
+	require.True(t, ok)
+	require.Equal(t, int64(2), version)
+
+	// the existing data is kept
+	height, appHash, err := meta.GetChainState(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), height)
+	require.Equal(t, []byte("app hash"), appHash)
+
+	param2, err := meta.LoadParams(ctx, tx)
+	require.NoError(t, err)
+	require.EqualValues(t, param, param2)
+
+	// the param history starts empty, and records changes after the upgrade
+	history, err := meta.GetParamHistory(ctx, tx, "max_block_size")
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	param3 := *param
+	param3.MaxBlockSize = 2000
+	err = meta.StoreDiff(ctx, tx, param, &param3, 10, 1700000000)
+	require.NoError(t, err)
+
+	history, err = meta.GetParamHistory(ctx, tx, "max_block_size")
+	require.NoError(t, err)
+	require.Equal(t, []*meta.ParamChange{
+		{Height: 10, Param: "max_block_size", OldValue: int64(1000), NewValue: int64(2000), Timestamp: 1700000000},
+	}, history)
+
+	// upgrading again is a no-op
+	err = meta.InitializeMetaStore(ctx, tx)
+	require.NoError(t, err)
+}
+
+// recordingTx is a sql.Tx that records the executed statements.
+type recordingTx struct {
+	stmts []string
+}
+
+func (r *recordingTx) BeginTx(ctx context.Context) (sql.Tx, error) { return r, nil }
+func (r *recordingTx) Rollback(ctx context.Context) error          { return nil }
+func (r *recordingTx) Commit(ctx context.Context) error            { return nil }
+
+func (r *recordingTx) Execute(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error) {
+	r.stmts = append(r.stmts, fmt.Sprint(stmt, args))
+	return &sql.ResultSet{}, nil
+}
+
+func Test_StoreDiffOrder(t *testing.T) {
+	ctx := context.Background()
+
+	original := &common.NetworkParameters{
+		MaxBlockSize: 1000,
+		JoinExpiry:   100,
+		VoteExpiry:   100,
+	}
+	updated := &common.NetworkParameters{
+		MaxBlockSize:     2000,
+		JoinExpiry:       200,
+		VoteExpiry:       300,
+		DisabledGasCosts: true,
+	}
+
+	var runs [][]string
+	for i := 0; i < 2; i++ {
+		tx := &recordingTx{}
+		err := meta.StoreDiff(ctx, tx, original, updated, 10, 1700000000)
+		require.NoError(t, err)
+		require.Len(t, tx.stmts, 8) // an upsert and a history insert for each
+		runs = append(runs, tx.stmts)
+	}
+	require.Equal(t, runs[0], runs[1])
+
+	// params are written in sorted order
+	for i, param := range []string{"disabled_gas_costs", "join_expiry", "max_block_size", "vote_expiry"} {
+		require.Contains(t, runs[0][2*i], param)
+		require.Contains(t, runs[0][2*i+1], param)
+	}
+}
+
+// resultExecutor is a sql.Executor that returns a fixed result.
+type resultExecutor struct {
+	res *sql.ResultSet
+}
+
+func (r *resultExecutor) Execute(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error) {
+	return r.res, nil
+}
+
+func Test_LoadParamsErrors(t *testing.T) {
+	int64Bytes := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+	validRows := func() [][]any {
+		return [][]any{
+			{"max_block_size", int64Bytes},
+			{"join_expiry", int64Bytes},
+			{"vote_expiry", int64Bytes},
+			{"disabled_gas_costs", []byte{1}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		rows    func() [][]any
+		wantErr error
+	}{
+		{"no rows", func() [][]any { return nil }, meta.ErrParamsNotFound},
+		{"missing row", func() [][]any { return validRows()[:3] }, meta.ErrParamCountMismatch},
+		{"extra row", func() [][]any {
+			return append(validRows(), []any{"max_block_size", int64Bytes})
+		}, meta.ErrParamCountMismatch},
+		{"extra column", func() [][]any {
+			rows := validRows()
+			rows[0] = append(rows[0], "extra")
+			return rows
+		}, meta.ErrParamColumnShape},
+		{"name not a string", func() [][]any {
+			rows := validRows()
+			rows[1][0] = int64(1)
+			return rows
+		}, meta.ErrParamColumnShape},
+		{"value not bytes", func() [][]any {
+			rows := validRows()
+			rows[2][1] = "1"
+			return rows
+		}, meta.ErrParamColumnShape},
+		{"unknown param", func() [][]any {
+			rows := validRows()
+			rows[3][0] = "not_a_param"
+			return rows
+		}, meta.ErrUnknownParam},
+		{"short value", func() [][]any {
+			rows := validRows()
+			rows[0][1] = []byte{1}
+			return rows
+		}, meta.ErrInvalidParamValue},
+		{"empty bool value", func() [][]any {
+			rows := validRows()
+			rows[3][1] = []byte{}
+			return rows
+		}, meta.ErrInvalidParamValue},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := meta.LoadParams(ctx, &resultExecutor{&sql.ResultSet{Rows: tt.rows()}})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	params, err := meta.LoadParams(ctx, &resultExecutor{&sql.ResultSet{Rows: validRows()}})
+	require.NoError(t, err)
+	require.Equal(t, &common.NetworkParameters{
+		MaxBlockSize:     1,
+		JoinExpiry:       1,
+		VoteExpiry:       1,
+		DisabledGasCosts: true,
+	}, params)
+}
@@ -24,5 +24,6 @@ const (
 	codeInsufficientBalance = transactions.CodeInsufficientBalance
 	codeInsufficientFee     = transactions.CodeInsufficientFee
 	codeInvalidAmount       = transactions.CodeInvalidAmount
+	codeTxExpired           = transactions.CodeTxExpired
 	codeUnknownError        = transactions.CodeUnknownError
 )
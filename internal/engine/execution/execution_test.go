@@ -3,6 +3,7 @@ package execution
 import (
 	"context"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/kwilteam/kwil-db/core/types"
 	"github.com/kwilteam/kwil-db/core/types/testdata"
 	"github.com/kwilteam/kwil-db/extensions/precompiles"
+	"github.com/kwilteam/kwil-db/internal/sql/pg"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -347,6 +349,59 @@ func Test_Execution(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "paged ad hoc query",
+			fn: func(t *testing.T, eng *GlobalContext) {
+				ctx := context.Background()
+				db := newDB(false)
+
+				err := eng.CreateDataset(ctx, db, testdata.TestSchema, &common.TransactionData{
+					Signer: testdata.TestSchema.Owner,
+					Caller: string(testdata.TestSchema.Owner),
+					TxID:   "txid1",
+				})
+				require.NoError(t, err)
+				dbid := testdata.TestSchema.DBID()
+
+				_, err = eng.QueryPage(ctx, db, dbid, "SELECT id FROM users ORDER BY age", nil, 3, 6)
+				require.NoError(t, err)
+				stmt := db.executedStmts[len(db.executedStmts)-1]
+				assert.True(t, strings.HasSuffix(stmt, "ORDER BY age ASC NULLS LAST, users.id LIMIT 3 OFFSET 6;"), stmt)
+
+				_, err = eng.QueryPage(ctx, db, dbid, "SELECT id FROM users LIMIT 10", nil, 3, 0)
+				assert.Error(t, err)
+
+				_, err = eng.QueryPage(ctx, db, dbid, "DELETE FROM users", nil, 3, 0)
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "ad hoc query parameters",
+			fn: func(t *testing.T, eng *GlobalContext) {
+				ctx := context.Background()
+				db := newDB(false)
+
+				err := eng.CreateDataset(ctx, db, testdata.TestSchema, &common.TransactionData{
+					Signer: testdata.TestSchema.Owner,
+					Caller: string(testdata.TestSchema.Owner),
+					TxID:   "txid1",
+				})
+				require.NoError(t, err)
+				dbid := testdata.TestSchema.DBID()
+
+				// Execute does not declare the values as variables.
+				_, err = eng.Execute(ctx, db, dbid, "SELECT id FROM users WHERE age = $age", map[string]any{"$age": int64(30)})
+				assert.Error(t, err)
+
+				// Query binds typed values as they are, and type-checks them.
+				_, err = eng.Query(ctx, db, dbid, "SELECT id FROM users WHERE age = $age", map[string]any{"$age": int64(30)})
+				require.NoError(t, err)
+				assert.Equal(t, []any{pg.QueryModeExec, int64(30)}, db.executedArgs[len(db.executedArgs)-1])
+
+				_, err = eng.Query(ctx, db, dbid, "SELECT id FROM users WHERE age = $age", map[string]any{"$age": "30"})
+				assert.Error(t, err)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -394,6 +449,7 @@ type mockDB struct {
 	accessMode    sql.AccessMode
 	dbs           map[string][]byte // serialized schemas
 	executedStmts []string
+	executedArgs  [][]any
 	resultSet     *sql.ResultSet
 }
 
@@ -429,6 +485,7 @@ func (m *mockDB) Execute(ctx context.Context, stmt string, args ...any) (*sql.Re
 		delete(m.dbs, args[0].(string))
 	default:
 		m.executedStmts = append(m.executedStmts, stmt)
+		m.executedArgs = append(m.executedArgs, args)
 
 		if m.resultSet != nil {
 			return m.resultSet, nil
@@ -12,6 +12,7 @@ import (
 	"github.com/kwilteam/kwil-db/common"
 	"github.com/kwilteam/kwil-db/common/sql"
 	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/decimal"
 	"github.com/kwilteam/kwil-db/extensions/precompiles"
 	"github.com/kwilteam/kwil-db/internal/engine/generate"
 	"github.com/kwilteam/kwil-db/internal/sql/pg"
@@ -328,6 +329,50 @@ func (g *GlobalContext) GetSchema(dbid string) (*types.Schema, error) {
 // Execute executes a SQL statement on a dataset. If the statement is mutative,
 // the tx must also be a sql.AccessModer. It uses Kwil's SQL dialect.
 func (g *GlobalContext) Execute(ctx context.Context, tx sql.DB, dbid, query string, values map[string]any) (*sql.ResultSet, error) {
+	return g.execute(ctx, tx, dbid, query, values, false, nil)
+}
+
+// Query is like Execute, but the values are typed bind parameters, such as
+// those of an RPC query request. The query may reference them by name, e.g.
+// $id, and is type-checked against their types. The values are passed to the
+// database as they are, rather than coerced like action arguments.
+func (g *GlobalContext) Query(ctx context.Context, tx sql.DB, dbid, query string, params map[string]any) (*sql.ResultSet, error) {
+	return g.execute(ctx, tx, dbid, query, params, true, nil)
+}
+
+// QueryPage is like Query, but for a SELECT statement, it returns at most
+// limit rows of the result, starting at offset. The LIMIT and OFFSET are added
+// to the generated SQL after the default ordering, so that only the rows in
+// the page are read. The statement may not have its own LIMIT or OFFSET.
+func (g *GlobalContext) QueryPage(ctx context.Context, tx sql.DB, dbid, query string, params map[string]any, limit, offset int64) (*sql.ResultSet, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, fmt.Errorf("invalid page limit %d and offset %d", limit, offset)
+	}
+	return g.execute(ctx, tx, dbid, query, params, true, &queryPage{limit: limit, offset: offset})
+}
+
+// queryPage is the LIMIT and OFFSET to apply to a paged query.
+type queryPage struct {
+	limit, offset int64
+}
+
+// apply sets the LIMIT and OFFSET of a SELECT statement.
+func (p *queryPage) apply(stmt *parse.SQLStatement) error {
+	sel, ok := stmt.SQL.(*parse.SelectStatement)
+	if !ok {
+		return errors.New("only SELECT statements can be paged")
+	}
+	if sel.Limit != nil || sel.Offset != nil {
+		return errors.New("cannot page a SELECT statement with a LIMIT or OFFSET")
+	}
+	sel.Limit = &parse.ExpressionLiteral{Type: types.IntType, Value: p.limit}
+	sel.Offset = &parse.ExpressionLiteral{Type: types.IntType, Value: p.offset}
+	return nil
+}
+
+// execute executes an ad hoc statement. If typed is true, the values are typed
+// bind parameters, as for Query.
+func (g *GlobalContext) execute(ctx context.Context, tx sql.DB, dbid, query string, values map[string]any, typed bool, page *queryPage) (*sql.ResultSet, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 	dataset, ok := g.datasets[dbid]
@@ -349,7 +394,17 @@ func (g *GlobalContext) Execute(ctx context.Context, tx sql.DB, dbid, query stri
 	// if errLis.Err() != nil {
 	// 	return nil, errLis.Err()
 	// }
-	res, err := parse.ParseSQL(query, dataset.schema)
+	var vars map[string]*types.DataType
+	if typed {
+		// The query may reference the parameters by name, e.g. $id, with
+		// types inferred from their Go types.
+		vars = make(map[string]*types.DataType, len(values))
+		for name, val := range values {
+			vars[name] = paramDataType(val)
+		}
+	}
+
+	res, err := parse.ParseSQLWithVars(query, dataset.schema, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +413,12 @@ func (g *GlobalContext) Execute(ctx context.Context, tx sql.DB, dbid, query stri
 		return nil, res.ParseErrs.Err()
 	}
 
+	if page != nil {
+		if err = page.apply(res.AST); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlStmt, params, err := generate.WriteSQL(res.AST, true, dbidSchema(dbid))
 	if err != nil {
 		return nil, err
@@ -373,12 +434,62 @@ func (g *GlobalContext) Execute(ctx context.Context, tx sql.DB, dbid, query stri
 		}
 	}
 
-	args := orderAndCleanValueMap(values, params)
+	var args []any
+	if typed {
+		// Unlike action arguments, the values are already typed, so they are
+		// not coerced like in orderAndCleanValueMap.
+		args = make([]any, 0, len(params))
+		for _, param := range params {
+			args = append(args, values[param])
+		}
+	} else {
+		args = orderAndCleanValueMap(values, params)
+	}
 	args = append([]any{pg.QueryModeExec}, args...)
 
 	return tx.Execute(ctx, sqlStmt, args...)
 }
 
+// paramDataType returns the data type of a query parameter value, such as the
+// values decoded from a transactions.EncodedValue. If the type cannot be
+// determined, e.g. for a nil value, it is the unknown type.
+func paramDataType(val any) *types.DataType {
+	switch v := val.(type) {
+	case string:
+		return types.TextType
+	case int64, int, int32:
+		return types.IntType
+	case bool:
+		return types.BoolType
+	case []byte:
+		return types.BlobType
+	case *types.UUID, types.UUID:
+		return types.UUIDType
+	case *types.Uint256:
+		return types.Uint256Type
+	case *decimal.Decimal:
+		dt, err := types.NewDecimalType(v.Precision(), v.Scale())
+		if err != nil {
+			return types.UnknownType
+		}
+		return dt
+	case []string:
+		return types.ArrayType(types.TextType)
+	case []int64:
+		return types.ArrayType(types.IntType)
+	case []bool:
+		return types.ArrayType(types.BoolType)
+	case [][]byte:
+		return types.ArrayType(types.BlobType)
+	case types.UUIDArray:
+		return types.ArrayType(types.UUIDType)
+	case types.Uint256Array:
+		return types.ArrayType(types.Uint256Type)
+	default:
+		return types.UnknownType
+	}
+}
+
 type dbQueryFn func(ctx context.Context, stmt string, args ...any) (*sql.ResultSet, error)
 
 // loadDataset loads a dataset into the global context.
@@ -30,6 +30,9 @@ import (
 type BlockchainTransactor interface {
 	Status(context.Context) (*types.Status, error)
 	Peers(context.Context) ([]*types.PeerInfo, error)
+	// UnconfirmedTxs returns up to limit transactions in the mempool. A limit
+	// of zero uses the node's default.
+	UnconfirmedTxs(ctx context.Context, limit int) ([]*types.PendingTx, error)
 	BroadcastTx(ctx context.Context, tx []byte, sync uint8) (*cmtCoreTypes.ResultBroadcastTx, error)
 }
 
@@ -88,6 +91,9 @@ func (svc *Service) Methods() map[jsonrpc.Method]rpcserver.MethodDef {
 		adminjson.MethodPeers: rpcserver.MakeMethodDef(svc.Peers,
 			"get the current peers of the node",
 			"a list of the node's current peers"),
+		adminjson.MethodPendingTxs: rpcserver.MakeMethodDef(svc.PendingTxs,
+			"list transactions in the node's mempool",
+			"the hash, size, sender, and nonce of each pending transaction"),
 		adminjson.MethodConfig: rpcserver.MakeMethodDef(svc.GetConfig,
 			"retrieve the current effective node config",
 			"the raw bytes of the effective config TOML document"),
@@ -180,6 +186,20 @@ func (svc *Service) Peers(ctx context.Context, _ *adminjson.PeersRequest) (*admi
 	}, nil
 }
 
+func (svc *Service) PendingTxs(ctx context.Context, req *adminjson.PendingTxsRequest) (*adminjson.PendingTxsResponse, *jsonrpc.Error) {
+	if req.Limit < 0 {
+		return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "negative limit", nil)
+	}
+	txs, err := svc.blockchain.UnconfirmedTxs(ctx, req.Limit)
+	if err != nil {
+		svc.log.Error("failed to get unconfirmed txs", zap.Error(err))
+		return nil, jsonrpc.NewError(jsonrpc.ErrorNodeInternal, "node mempool unavailable", nil)
+	}
+	return &adminjson.PendingTxsResponse{
+		Txs: txs,
+	}, nil
+}
+
 // sendTx makes a transaction and sends it to the local node.
 func (svc *Service) sendTx(ctx context.Context, payload transactions.Payload) (*userjson.BroadcastResponse, *jsonrpc.Error) {
 	readTx := svc.db.BeginDelayedReadTx()
@@ -353,10 +373,8 @@ func toPendingInfo(ctx context.Context, db sql.DB, resolution *resolutions.Resol
 	// this will result with all approvers at the start of the list, and all voters at the end.
 	// finally, the approvals will be true for the length of the approvers, and false for found.length - voters.length
 	board := make([][]byte, 0, len(allVoters))
-	approvals := make([]bool, len(allVoters))
-	for i, v := range resolution.Voters {
+	for _, v := range resolution.Voters {
 		board = append(board, v.PubKey)
-		approvals[i] = true
 	}
 	for _, v := range allVoters {
 		board = append(board, v.PubKey)
@@ -373,6 +391,13 @@ func toPendingInfo(ctx context.Context, db sql.DB, resolution *resolutions.Resol
 		found[string(board[i])] = struct{}{}
 	}
 
+	// The board may be larger than the current validator set if an approver
+	// has since left, so size the approvals to the board.
+	approvals := make([]bool, len(board))
+	for i := range resolution.Voters {
+		approvals[i] = true
+	}
+
 	return &adminjson.PendingJoin{
 		Candidate: resolution.Proposer,
 		Power:     resolutionBody.Power,
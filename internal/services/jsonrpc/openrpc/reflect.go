@@ -210,6 +210,12 @@ func reflectTypeInfo(t reflect.Type, knownSchemas map[reflect.Type]Schema) Schem
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
 
+			// Like encoding/json, skip unexported fields, except embedded ones
+			// which may have exported fields of their own.
+			if !field.Anonymous && !field.IsExported() {
+				continue
+			}
+
 			if field.Anonymous { // embedded field
 				fieldType := field.Type
 				if fieldType.Kind() == reflect.Struct { // merge properties of embedded struct
@@ -254,6 +260,12 @@ func reflectTypeInfo(t reflect.Type, knownSchemas map[reflect.Type]Schema) Schem
 		schema.Items = &ti
 		return schema
 
+	case reflect.Map:
+		// Represent maps as an object with arbitrary property names. The value
+		// type is not described.
+		schema.AdditionalProperties = true
+		return schema
+
 	case reflect.Interface:
 		// Represent interfaces as a generic object, assuming no specific
 		// properties can be inferred.
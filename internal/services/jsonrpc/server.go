@@ -421,6 +421,11 @@ func (s *Server) handlerV1(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "error reading request body", http.StatusBadRequest)
 		return
 	}
+	if isBatch(body) {
+		s.processBatch(r.Context(), w, body)
+		return
+	}
+
 	req := new(jsonrpc.Request)
 	err = json.Unmarshal(body, req)
 	if err != nil {
@@ -458,6 +463,48 @@ func (s *Server) processRequest(ctx context.Context, w http.ResponseWriter, req
 	s.writeJSON(w, resp, statusCode)
 }
 
+// maxBatchSize is the maximum number of requests permitted in a batch.
+const maxBatchSize = 100
+
+// isBatch checks if the request body is a JSON array, indicating a batch of
+// requests rather than a single request object.
+func isBatch(body []byte) bool {
+	body = bytes.TrimLeft(body, " \t\r\n")
+	return len(body) > 0 && body[0] == '['
+}
+
+// processBatch handles a batch of requests, responding with an array of
+// responses in the same order. Unlike processRequest, the http status code is
+// only an error if the batch itself is invalid. Errors for individual requests
+// are only indicated by the Error field of the corresponding response.
+func (s *Server) processBatch(ctx context.Context, w http.ResponseWriter, body []byte) {
+	var reqs []*jsonrpc.Request
+	err := json.Unmarshal(body, &reqs)
+	if err != nil {
+		resp := jsonrpc.NewErrorResponse(-1, jsonrpc.NewError(jsonrpc.ErrorParse, "invalid request", nil))
+		s.writeJSON(w, resp, http.StatusBadRequest)
+		return
+	}
+	if len(reqs) == 0 || len(reqs) > maxBatchSize {
+		msg := fmt.Sprintf("batch must contain between 1 and %d requests", maxBatchSize)
+		resp := jsonrpc.NewErrorResponse(-1, jsonrpc.NewError(jsonrpc.ErrorInvalidRequest, msg, nil))
+		s.writeJSON(w, resp, http.StatusBadRequest)
+		return
+	}
+
+	resps := make([]*jsonrpc.Response, len(reqs))
+	for i, req := range reqs {
+		if req == nil { // e.g. [null]
+			rpcErr := jsonrpc.NewError(jsonrpc.ErrorInvalidRequest, "invalid json-rpc request object", nil)
+			resps[i] = jsonrpc.NewErrorResponse(nil, rpcErr)
+			continue
+		}
+		resps[i] = s.handleRequest(ctx, req)
+	}
+
+	s.writeJSON(w, resps, http.StatusOK)
+}
+
 // writeJSONWithStatus marshals the provided interface and writes the bytes to
 // the ResponseWriter with the specified response code.
 func (s *Server) writeJSON(w http.ResponseWriter, thing any, code int) {
@@ -1,9 +1,11 @@
 package rpcserver
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -68,3 +70,50 @@ func Test_timeout(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, resp.Error.Code, jsonrpc.ErrorTimeout)
 }
+
+func Test_batch(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0", log.NewNoOp())
+	require.NoError(t, err)
+	s.RegisterMethodHandler("echo", func(ctx context.Context, s *Server) (any, func() (any, *jsonrpc.Error)) {
+		req := new(string)
+		return req, func() (any, *jsonrpc.Error) {
+			if *req == "" {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "empty message", nil)
+			}
+			return *req, nil
+		}
+	})
+
+	body := `[
+		{"jsonrpc":"2.0","id":"1","method":"echo","params":"a"},
+		{"jsonrpc":"2.0","id":"2","method":"echo","params":""},
+		{"jsonrpc":"2.0","id":"3","method":"nope","params":"c"}
+	]`
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, pathRPCV1, strings.NewReader(body))
+	s.handlerV1(w, r)
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var resps []*jsonrpc.Response
+	err = json.NewDecoder(w.Body).Decode(&resps)
+	require.NoError(t, err)
+	require.Len(t, resps, 3)
+
+	assert.Equal(t, "1", resps[0].ID)
+	assert.Nil(t, resps[0].Error)
+	assert.JSONEq(t, `"a"`, string(resps[0].Result))
+
+	assert.Equal(t, "2", resps[1].ID)
+	require.NotNil(t, resps[1].Error)
+	assert.Equal(t, jsonrpc.ErrorInvalidParams, resps[1].Error.Code)
+
+	assert.Equal(t, "3", resps[2].ID)
+	require.NotNil(t, resps[2].Error)
+	assert.Equal(t, jsonrpc.ErrorUnknownMethod, resps[2].Error.Code)
+
+	// An empty batch is an invalid request.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, pathRPCV1, strings.NewReader(" []"))
+	s.handlerV1(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
@@ -0,0 +1,68 @@
+package usersvc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/kwilteam/kwil-db/core/types/serialize"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+)
+
+// Query cursors are stateless. A cursor encodes the offset of the next row and
+// a digest of the query and parameters it belongs to. Each page is read with a
+// LIMIT and OFFSET after the query's deterministic ordering, so the pages are
+// consistent as long as the data does not change between requests.
+
+const queryCursorDigestLen = 8
+
+// queryDigest identifies the query and parameter values to which a cursor
+// belongs, so that a cursor is not used with a different query or parameters.
+func queryDigest(dbid, query string, params map[string]*transactions.EncodedValue) ([]byte, error) {
+	h := sha256.New()
+	h.Write([]byte(dbid + "\x00" + query + "\x00"))
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		val, err := serialize.Encode(params[name])
+		if err != nil {
+			return nil, err
+		}
+		h.Write([]byte(name + "\x00"))
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(val))))
+		h.Write(val)
+	}
+	return h.Sum(nil)[:queryCursorDigestLen], nil
+}
+
+func encodeQueryCursor(offset int64, digest []byte) string {
+	b := binary.BigEndian.AppendUint64(nil, uint64(offset))
+	b = append(b, digest...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeQueryCursor decodes the offset in a cursor for the query with the given
+// digest. The offset is checked against the page size, which must be positive
+// and less than math.MaxInt64, so that reading the page and one more row, and
+// the offset of the next page, do not overflow.
+func decodeQueryCursor(cursor string, digest []byte, pageSize int64) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) != 8+queryCursorDigestLen {
+		return 0, errors.New("invalid cursor")
+	}
+	if !bytes.Equal(b[8:], digest) {
+		return 0, errors.New("cursor is for a different query")
+	}
+	offset := binary.BigEndian.Uint64(b[:8])
+	if offset > math.MaxInt64-uint64(pageSize)-1 {
+		return 0, errors.New("invalid cursor")
+	}
+	return int64(offset), nil
+}
@@ -0,0 +1,73 @@
+package usersvc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustEncodeValue(t *testing.T, v any) *transactions.EncodedValue {
+	ev, err := transactions.EncodeValue(v)
+	require.NoError(t, err)
+	return ev
+}
+
+func Test_queryDigest(t *testing.T) {
+	const dbid, query = "xdbid", "SELECT id FROM users WHERE age > $age"
+	params := map[string]*transactions.EncodedValue{"$age": mustEncodeValue(t, int64(20))}
+
+	digest, err := queryDigest(dbid, query, params)
+	require.NoError(t, err)
+	assert.Len(t, digest, queryCursorDigestLen)
+
+	same, err := queryDigest(dbid, query, map[string]*transactions.EncodedValue{"$age": mustEncodeValue(t, int64(20))})
+	require.NoError(t, err)
+	assert.Equal(t, digest, same)
+
+	for _, other := range []map[string]*transactions.EncodedValue{
+		nil,
+		{"$age": mustEncodeValue(t, int64(21))},
+		{"$age": mustEncodeValue(t, "20")},
+		{"$age": mustEncodeValue(t, int64(20)), "$name": mustEncodeValue(t, "a")},
+	} {
+		otherDigest, err := queryDigest(dbid, query, other)
+		require.NoError(t, err)
+		assert.NotEqual(t, digest, otherDigest)
+	}
+}
+
+func Test_decodeQueryCursor(t *testing.T) {
+	digest := func(dbid, query string) []byte {
+		d, err := queryDigest(dbid, query, nil)
+		require.NoError(t, err)
+		return d
+	}
+	cursor := encodeQueryCursor(42, digest("xdbid", "SELECT 1"))
+
+	offset, err := decodeQueryCursor(cursor, digest("xdbid", "SELECT 1"), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), offset)
+
+	_, err = decodeQueryCursor(cursor, digest("xdbid", "SELECT 2"), 10)
+	assert.Error(t, err)
+	_, err = decodeQueryCursor(cursor, digest("otherdbid", "SELECT 1"), 10)
+	assert.Error(t, err)
+	_, err = decodeQueryCursor("not a cursor", digest("xdbid", "SELECT 1"), 10)
+	assert.Error(t, err)
+
+	// The offset plus the page size and the extra row must not overflow.
+	d := digest("xdbid", "SELECT 1")
+	offset, err = decodeQueryCursor(encodeQueryCursor(math.MaxInt64-10, d), d, 9)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64-10), offset)
+	_, err = decodeQueryCursor(encodeQueryCursor(math.MaxInt64-10, d), d, 10)
+	assert.Error(t, err)
+	_, err = decodeQueryCursor(encodeQueryCursor(1<<62, d), d, 1<<62)
+	assert.Error(t, err)
+	_, err = decodeQueryCursor(encodeQueryCursor(-1, d), d, 1) // offset > MaxInt64
+	assert.Error(t, err)
+}
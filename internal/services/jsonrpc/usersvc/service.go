@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
 	"time"
 
 	// BlockchainTransactor returns have some big structs from cometbft.
@@ -25,6 +27,7 @@ import (
 	"github.com/kwilteam/kwil-db/internal/engine/execution" // errors from engine
 	rpcserver "github.com/kwilteam/kwil-db/internal/services/jsonrpc"
 	"github.com/kwilteam/kwil-db/internal/version"
+	"github.com/kwilteam/kwil-db/internal/voting"
 )
 
 // Service is the "user" RPC service, also known as txsvc in other contexts.
@@ -81,7 +84,7 @@ func NewService(db sql.DelayedReadTxMaker, engine EngineReader, chainClient Bloc
 // or any other breaking changes.
 const (
 	apiVerUserMajor = 0
-	apiVerUserMinor = 1
+	apiVerUserMinor = 2
 	apiVerUserPatch = 0
 )
 
@@ -144,6 +147,11 @@ func (svc *Service) Methods() map[jsonrpc.Method]rpcserver.MethodDef {
 			"get a deployed database's kuneiform schema definition",
 			"the kuneiform schema",
 		),
+		userjson.MethodValidators: rpcserver.MakeMethodDef(
+			svc.Validators,
+			"get the current validator set",
+			"the validators' public keys and voting power",
+		),
 		userjson.MethodTxQuery: rpcserver.MakeMethodDef(
 			svc.TxQuery,
 			"query for the status of a transaction",
@@ -175,7 +183,8 @@ type EngineReader interface {
 	Procedure(ctx context.Context, tx sql.DB, options *common.ExecutionData) (*sql.ResultSet, error)
 	GetSchema(dbid string) (*types.Schema, error)
 	ListDatasets(owner []byte) ([]*types.DatasetIdentifier, error)
-	Execute(ctx context.Context, tx sql.DB, dbid string, query string, values map[string]any) (*sql.ResultSet, error)
+	Query(ctx context.Context, tx sql.DB, dbid string, query string, params map[string]any) (*sql.ResultSet, error)
+	QueryPage(ctx context.Context, tx sql.DB, dbid string, query string, params map[string]any, limit, offset int64) (*sql.ResultSet, error)
 }
 
 // NOTE:
@@ -206,6 +215,23 @@ func (svc *Service) ChainInfo(ctx context.Context, req *userjson.ChainInfoReques
 	}, nil
 }
 
+// Validators returns the current validator set. Unlike the admin service's
+// ListValidators, this is available to any user since it is public.
+func (svc *Service) Validators(ctx context.Context, req *userjson.ValidatorsRequest) (*userjson.ValidatorsResponse, *jsonrpc.Error) {
+	readTx := svc.db.BeginDelayedReadTx()
+	defer readTx.Rollback(ctx)
+
+	vals, err := voting.GetValidators(ctx, readTx)
+	if err != nil {
+		svc.log.Error("failed to retrieve validators", log.Error(err))
+		return nil, jsonrpc.NewError(jsonrpc.ErrorDBInternal, "failed to retrieve validators", nil)
+	}
+
+	return &userjson.ValidatorsResponse{
+		Validators: vals,
+	}, nil
+}
+
 func (svc *Service) Broadcast(ctx context.Context, req *userjson.BroadcastRequest) (*userjson.BroadcastResponse, *jsonrpc.Error) {
 	logger := svc.log.With(log.String("rpc", "Broadcast"), // new logger each time, ick
 		log.String("PayloadType", req.Tx.Body.PayloadType))
@@ -315,23 +341,78 @@ func (svc *Service) Query(ctx context.Context, req *userjson.QueryRequest) (*use
 	ctxExec, cancel := context.WithTimeout(ctx, svc.readTxTimeout)
 	defer cancel()
 
+	var params map[string]any
+	if len(req.Params) > 0 {
+		params = make(map[string]any, len(req.Params))
+		for name, val := range req.Params {
+			if !strings.HasPrefix(name, "$") {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "query parameter name must start with $: "+name, nil)
+			}
+			if val == nil {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "missing value for query parameter "+name, nil)
+			}
+			decoded, err := val.Decode()
+			if err != nil {
+				return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "failed to decode query parameter "+name+": "+err.Error(), nil)
+			}
+			params[name] = decoded
+		}
+	}
+
+	var offset int64
+	var digest []byte
+	if req.PageSize > math.MaxInt64-1 { // one more row than the page is read
+		return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "page size too large", nil)
+	}
+	if req.PageSize > 0 {
+		var err error
+		digest, err = queryDigest(req.DBID, req.Query, req.Params)
+		if err != nil {
+			return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "failed to encode query parameters: "+err.Error(), nil)
+		}
+	}
+	if req.Cursor != "" {
+		if req.PageSize <= 0 {
+			return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, "cursor requires a page size", nil)
+		}
+		var err error
+		offset, err = decodeQueryCursor(req.Cursor, digest, req.PageSize)
+		if err != nil {
+			return nil, jsonrpc.NewError(jsonrpc.ErrorInvalidParams, err.Error(), nil)
+		}
+	}
+
 	readTx := svc.db.BeginDelayedReadTx()
 	defer readTx.Rollback(ctx)
 
-	result, err := svc.engine.Execute(ctxExec, readTx, req.DBID, req.Query, nil)
+	var result *sql.ResultSet
+	var err error
+	if req.PageSize > 0 {
+		// Read one row past the page to know if there is another page.
+		result, err = svc.engine.QueryPage(ctxExec, readTx, req.DBID, req.Query, params, req.PageSize+1, offset)
+	} else {
+		result, err = svc.engine.Query(ctxExec, readTx, req.DBID, req.Query, params)
+	}
 	if err != nil {
 		// We don't know for sure that it's an invalid argument, but an invalid
 		// user-provided query isn't an internal server error.
 		return nil, engineError(err)
 	}
 
+	var nextCursor string
+	if req.PageSize > 0 && int64(len(result.Rows)) > req.PageSize {
+		result.Rows = result.Rows[:req.PageSize]
+		nextCursor = encodeQueryCursor(offset+req.PageSize, digest)
+	}
+
 	bts, err := json.Marshal(resultMap(result)) // marshalling the map is less efficient, but necessary for backwards compatibility
 	if err != nil {
 		return nil, jsonrpc.NewError(jsonrpc.ErrorResultEncoding, "failed to marshal call result", nil)
 	}
 
 	return &userjson.QueryResponse{
-		Result: bts,
+		Result:     bts,
+		NextCursor: nextCursor,
 	}, nil
 }
 
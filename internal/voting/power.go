@@ -0,0 +1,128 @@
+package voting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kwilteam/kwil-db/common"
+	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+	"github.com/kwilteam/kwil-db/extensions/resolutions"
+)
+
+// This file implements validator power updates. Unlike joins and removals, they
+// are not enabled at startup. The route and resolution are registered by the
+// "validator_power" hardfork in extensions/consensus.
+
+// ValidatorPowerResolution is the resolution that sets an existing validator's
+// power once enough validators have voted for the same target and power.
+var ValidatorPowerResolution = resolutions.ResolutionConfig{
+	ConfirmationThreshold: big.NewRat(2, 3),
+	ResolveFunc: func(ctx context.Context, app *common.App, resolution *resolutions.Resolution, block *common.BlockContext) error {
+		powerReq := &UpdatePowerRequest{}
+		if err := powerReq.UnmarshalBinary(resolution.Body); err != nil {
+			return fmt.Errorf("failed to unmarshal power update request: %w", err)
+		}
+		if powerReq.Power <= 0 {
+			// the route only creates these with positive power
+			return fmt.Errorf("power update request with non-positive power")
+		}
+
+		// The validator may have been removed since the update was
+		// proposed, in which case it should not be re-added.
+		power, err := GetValidatorPower(ctx, app.DB, powerReq.PubKey)
+		if err != nil {
+			return err
+		}
+		if power == 0 {
+			return nil
+		}
+
+		return SetValidatorPower(ctx, app.DB, powerReq.PubKey, powerReq.Power)
+	},
+}
+
+// ValidatorPowerRoute is the transaction route for the validator_power payload,
+// with which a validator votes to change another validator's power.
+type ValidatorPowerRoute struct {
+	target []byte
+	power  int64
+}
+
+func (d *ValidatorPowerRoute) Name() string {
+	return transactions.PayloadTypeValidatorPower.String()
+}
+
+func (d *ValidatorPowerRoute) Price(ctx context.Context, app *common.App, tx *transactions.Transaction) (*big.Int, error) {
+	return big.NewInt(100_000), nil
+}
+
+func (d *ValidatorPowerRoute) PreTx(ctx common.TxContext, svc *common.Service, tx *transactions.Transaction) (transactions.TxCode, error) {
+	update := &transactions.ValidatorUpdatePower{}
+	err := update.UnmarshalBinary(tx.Body.Payload)
+	if err != nil {
+		return transactions.CodeEncodingError, err
+	}
+	// The payload is only executed after the hardfork, so unlike the older
+	// payloads, malformed ones can be rejected here.
+	if err = update.Validate(); err != nil {
+		return transactions.CodeEncodingError, err
+	}
+
+	d.target = update.Target
+	d.power = update.NewPower
+	return 0, nil
+}
+
+func (d *ValidatorPowerRoute) InTx(ctx common.TxContext, app *common.App, tx *transactions.Transaction) (transactions.TxCode, error) {
+	// ensure the sender is a validator
+	power, err := GetValidatorPower(ctx.Ctx, app.DB, tx.Sender)
+	if err != nil {
+		return transactions.CodeUnknownError, err
+	}
+	if power <= 0 {
+		return transactions.CodeInvalidSender, errors.New("caller is not a validator")
+	}
+
+	// ensure the target is a validator, since joining is done with a join
+	// request rather than a power update
+	power, err = GetValidatorPower(ctx.Ctx, app.DB, d.target)
+	if err != nil {
+		return transactions.CodeUnknownError, err
+	}
+	if power <= 0 {
+		return transactions.CodeInvalidSender, errors.New("target is not a validator")
+	}
+
+	powerReq := &UpdatePowerRequest{
+		PubKey: d.target,
+		Power:  d.power,
+	}
+	bts, err := powerReq.MarshalBinary()
+	if err != nil {
+		return transactions.CodeUnknownError, err
+	}
+
+	event := &types.VotableEvent{
+		Body: bts,
+		Type: ValidatorPowerEventType,
+	}
+
+	// Like a removal, the first vote creates the resolution. Votes for a
+	// different power for the same target are a different resolution.
+	err = CreateResolution(ctx.Ctx, app.DB, event, ctx.BlockContext.Height+ctx.BlockContext.ChainContext.NetworkParameters.JoinExpiry, tx.Sender)
+	if errors.Is(err, ErrResolutionAlreadyHasBody) {
+		app.Service.Logger.Debug("validator power update resolution already exists")
+	} else if err != nil {
+		return transactions.CodeUnknownError, err
+	}
+
+	err = ApproveResolution(ctx.Ctx, app.DB, event.ID(), tx.Sender)
+	if err != nil {
+		return transactions.CodeUnknownError, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,38 @@
+package voting_test
+
+import (
+	"testing"
+
+	"github.com/kwilteam/kwil-db/common"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
+	"github.com/kwilteam/kwil-db/internal/voting"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidatorPowerRoutePreTx(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload *transactions.ValidatorUpdatePower
+		code    transactions.TxCode
+	}{
+		{"valid", &transactions.ValidatorUpdatePower{Target: []byte("validator"), NewPower: 5}, 0},
+		{"no target", &transactions.ValidatorUpdatePower{NewPower: 5}, transactions.CodeEncodingError},
+		{"zero power", &transactions.ValidatorUpdatePower{Target: []byte("validator")}, transactions.CodeEncodingError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := transactions.CreateTransaction(tt.payload, "chain", 1)
+			require.NoError(t, err)
+
+			route := &voting.ValidatorPowerRoute{}
+			code, err := route.PreTx(common.TxContext{}, &common.Service{}, tx)
+			require.Equal(t, tt.code, code)
+			if tt.code == 0 {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, transactions.ErrMalformedPayload)
+			}
+		})
+	}
+}
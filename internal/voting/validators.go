@@ -15,6 +15,7 @@ import (
 const (
 	ValidatorJoinEventType   = "validator_join"
 	ValidatorRemoveEventType = "validator_remove"
+	ValidatorPowerEventType  = "validator_power"
 )
 
 func init() {
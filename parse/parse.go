@@ -274,6 +274,13 @@ type SQLParseResult struct {
 // It requires a schema to be passed in, since SQL statements may reference
 // schema objects.
 func ParseSQL(sql string, schema *types.Schema) (res *SQLParseResult, err error) {
+	return ParseSQLWithVars(sql, schema, nil)
+}
+
+// ParseSQLWithVars parses an SQL statement that may reference the provided
+// variables, such as bind parameters for an ad hoc query. The variable names
+// must include their prefix, e.g. "$id". Otherwise, it is the same as ParseSQL.
+func ParseSQLWithVars(sql string, schema *types.Schema, variables map[string]*types.DataType) (res *SQLParseResult, err error) {
 	if sql == "" {
 		return nil, fmt.Errorf("empty SQL statement")
 	}
@@ -291,13 +298,17 @@ func ParseSQL(sql string, schema *types.Schema) (res *SQLParseResult, err error)
 	visitor := &sqlAnalyzer{
 		blockContext: blockContext{
 			schema:             schema,
-			variables:          make(map[string]*types.DataType), // no variables exist for pure SQL calls
+			variables:          make(map[string]*types.DataType, len(variables)),
 			anonymousVariables: make(map[string]map[string]*types.DataType),
 			errs:               errLis,
 		},
 		sqlCtx: newSQLContext(),
 	}
 
+	for name, dt := range variables {
+		visitor.blockContext.variables[name] = dt
+	}
+
 	defer func() {
 		err2 := deferFn(recover())
 		if err2 != nil {
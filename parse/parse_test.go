@@ -2774,3 +2774,32 @@ var tableBalances = &types.Table{
 		},
 	},
 }
+
+func Test_SQLWithVars(t *testing.T) {
+	schema := &types.Schema{
+		Name:   "mydb",
+		Tables: []*types.Table{tblUsers, tblPosts},
+	}
+	const sql = "select * from users where id = $id and username = $name;"
+
+	// without declared variables, they are undeclared
+	res, err := parse.ParseSQL(sql, schema)
+	require.NoError(t, err)
+	require.ErrorIs(t, res.ParseErrs.Err(), parse.ErrUndeclaredVariable)
+
+	res, err = parse.ParseSQLWithVars(sql, schema, map[string]*types.DataType{
+		"$id":   types.IntType,
+		"$name": types.TextType,
+	})
+	require.NoError(t, err)
+	require.NoError(t, res.ParseErrs.Err())
+	require.False(t, res.Mutative)
+
+	// declared types are checked
+	res, err = parse.ParseSQLWithVars(sql, schema, map[string]*types.DataType{
+		"$id":   types.TextType,
+		"$name": types.TextType,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, res.ParseErrs.Err(), parse.ErrType)
+}
@@ -56,6 +56,11 @@ type ActTestCfg struct {
 	CreatorSigner auth.Signer
 	VisitorSigner auth.Signer
 
+	// Ed25519RawPK is the key of a user with an ed25519 key, rather than a
+	// secp256k1 key like the creator and visitor.
+	Ed25519RawPK  string
+	Ed25519Signer auth.Signer
+
 	GasEnabled bool
 }
 
@@ -129,6 +134,7 @@ func (r *ActHelper) LoadConfig() *ActTestCfg {
 		// NOTE: these ENVs are used to test remote services
 		CreatorRawPk:              getEnv("KACT_CREATOR_PK", "f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e"),
 		VisitorRawPK:              getEnv("KACT_VISITOR_PK", "43f149de89d64bf9a9099be19e1b1f7a4db784af8fa07caf6f08dc86ba65636b"),
+		Ed25519RawPK:              getEnv("KACT_ED25519_PK", "4c922f2ab842daa18e70440cb1a9dbb7c76a9e5d96fc080fd2345dba604766e689f12c96b976f08c8daebbc2b034f7d0ed9dd52e7d7d2baaef22957075308033"),
 		SchemaFile:                getEnv("KACT_SCHEMA", "./test-data/test_db.kf"),
 		LogLevel:                  getEnv("KACT_LOG_LEVEL", "info"),
 		JSONRPCEndpoint:           getEnv("KACT_JSONRPC_ENDPOINT", "http://127.0.0.1:8484"),
@@ -155,6 +161,10 @@ func (r *ActHelper) LoadConfig() *ActTestCfg {
 	require.NoError(r.t, err, "invalid visitor private key")
 	cfg.VisitorSigner = &auth.EthPersonalSigner{Key: *bobPk}
 
+	edPk, err := crypto.Ed25519PrivateKeyFromHex(cfg.Ed25519RawPK)
+	require.NoError(r.t, err, "invalid ed25519 private key")
+	cfg.Ed25519Signer = &auth.Ed25519Signer{Ed25519PrivateKey: *edPk}
+
 	r.cfg = cfg
 	//cfg.DumpToEnv()
 
@@ -277,12 +287,18 @@ func (r *ActHelper) WaitUntilInterrupt() {
 
 // GetDriver returns a concrete driver for acceptance test, based on the driver
 // type and user. By default, the driver is created with the creator's private key.
+// The "visitor" user has another secp256k1 key, and the "ed25519" user has an
+// ed25519 key.
 func (r *ActHelper) GetDriver(driveType string, user string) KwilAcceptanceDriver {
 	pk := r.cfg.CreatorRawPk
 	signer := r.cfg.CreatorSigner
-	if user == "visitor" {
+	switch user {
+	case "visitor":
 		signer = r.cfg.VisitorSigner
 		pk = r.cfg.VisitorRawPK
+	case "ed25519":
+		signer = r.cfg.Ed25519Signer
+		pk = r.cfg.Ed25519RawPK
 	}
 
 	switch driveType {
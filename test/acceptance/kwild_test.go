@@ -2,6 +2,7 @@ package acceptance_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"flag"
 	"math/big"
 	"strings"
@@ -218,6 +219,40 @@ func TestKwildAcceptance(t *testing.T) {
 	}
 }
 
+// TestKwildEd25519Acceptance checks that a user with an ed25519 key, rather
+// than a secp256k1 key, can deploy a database and execute actions with each
+// driver.
+func TestKwildEd25519Acceptance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	if *parallelMode {
+		t.Parallel()
+	}
+
+	ctx := context.Background()
+	testDrivers := strings.Split(*drivers, ",")
+	for _, driverType := range testDrivers {
+		t.Run(driverType+"_driver", func(t *testing.T) {
+			helper := acceptance.NewActHelper(t)
+			helper.LoadConfig()
+			if !*remote {
+				helper.Setup(ctx)
+			}
+			edDriver := helper.GetDriver(driverType, "ed25519")
+
+			ident, err := edDriver.Identifier()
+			require.NoError(t, err)
+			assert.Len(t, ident, 2*ed25519.PublicKeySize) // hex public key
+
+			specifications.DatabaseDeploySpecification(ctx, t, edDriver)
+			specifications.ExecuteOwnerActionSpecification(ctx, t, edDriver)
+			specifications.DatabaseDropSpecification(ctx, t, edDriver)
+		})
+	}
+}
+
 // TestTypes checks that type serialization works correctly over RLP, JSON,
 // and Postgres.
 func TestTypes(t *testing.T) {
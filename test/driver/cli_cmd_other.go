@@ -0,0 +1,9 @@
+//go:build !unix
+
+package driver
+
+import "os/exec"
+
+// killProcessGroupOnCancel is a no-op on platforms without process groups.
+// The command's WaitDelay still bounds how long it may outlive its context.
+func killProcessGroupOnCancel(cmd *exec.Cmd) {}
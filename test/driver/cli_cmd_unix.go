@@ -0,0 +1,19 @@
+//go:build unix
+
+package driver
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroupOnCancel runs the command in its own process group, and
+// kills the whole group when the command's context is cancelled. This is needed
+// for shell pipelines, where killing only the shell leaves its children running
+// with the command's stdout open.
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
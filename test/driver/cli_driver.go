@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -18,11 +19,12 @@ import (
 	"time"
 
 	ec "github.com/ethereum/go-ethereum/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto"
 	"github.com/kwilteam/kwil-db/core/crypto/auth"
 	"github.com/kwilteam/kwil-db/core/log"
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
-	"github.com/kwilteam/kwil-db/core/utils"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
 	jsonUtil "github.com/kwilteam/kwil-db/core/utils/json"
 	ethdeployer "github.com/kwilteam/kwil-db/test/integration/eth-deployer"
 
@@ -54,28 +56,46 @@ func NewKwilCliDriver(cliBin, rpcURL, privKey, chainID string, identity []byte,
 	}
 }
 
-// newKwilCliCmd returns a new exec.Cmd for kwil-cli
-func (d *KwilCliDriver) newKwilCliCmd(args ...string) *exec.Cmd {
+// keyType returns the kwil-cli key type of the driver's private key, which is
+// either a 32 byte secp256k1 key or a 64 byte ed25519 key.
+func (d *KwilCliDriver) keyType() string {
+	if len(strings.TrimPrefix(d.privKey, "0x")) == 2*ed25519.PrivateKeySize {
+		return "ed25519"
+	}
+	return "secp256k1"
+}
+
+// cmdWaitDelay is how long a kwil-cli command may keep its output open after
+// it is killed by its context, e.g. if it leaves child processes running.
+const cmdWaitDelay = 5 * time.Second
+
+// newKwilCliCmd returns a new exec.Cmd for kwil-cli. The command is killed if
+// the context is cancelled before it completes.
+func (d *KwilCliDriver) newKwilCliCmd(ctx context.Context, args ...string) *exec.Cmd {
 	args = append(args, "--provider", d.rpcURL)
 	args = append(args, "--private-key", d.privKey)
+	args = append(args, "--key-type", d.keyType())
 	args = append(args, "--chain-id", d.chainID)
 	args = append(args, "--output", "json")
 
 	d.logger.Info("cli Cmd", zap.String("args",
 		strings.Join(append([]string{d.cliBin}, args...), " ")))
 
-	cmd := exec.Command(d.cliBin, args...)
+	cmd := exec.CommandContext(ctx, d.cliBin, args...)
+	cmd.WaitDelay = cmdWaitDelay
 	return cmd
 }
 
 // newKwilCliCmdWithYes this is a helper function to automatically answer yes to
 // all prompts. This is useful for testing.
-// The cmd will be executed as `yes | kwil-cli <args>`
-func (d *KwilCliDriver) newKwilCliCmdWithYes(args ...string) *exec.Cmd {
+// The cmd will be executed as `yes | kwil-cli <args>`. Like newKwilCliCmd, the
+// whole pipeline is killed if the context is cancelled.
+func (d *KwilCliDriver) newKwilCliCmdWithYes(ctx context.Context, args ...string) *exec.Cmd {
 	args = append([]string{"yes |", d.cliBin}, args...)
 
 	args = append(args, "--provider", d.rpcURL)
 	args = append(args, "--private-key", d.privKey)
+	args = append(args, "--key-type", d.keyType())
 	args = append(args, "--chain-id", d.chainID)
 	args = append(args, "--output", "json")
 
@@ -84,7 +104,9 @@ func (d *KwilCliDriver) newKwilCliCmdWithYes(args ...string) *exec.Cmd {
 	d.logger.Info("cli Cmd(with yes)", zap.String("args",
 		strings.Join(append([]string{"bash", "-c"}, s), " ")))
 
-	cmd := exec.Command("bash", "-c", s)
+	cmd := exec.CommandContext(ctx, "bash", "-c", s)
+	killProcessGroupOnCancel(cmd) // kill yes and kwil-cli too, not just bash
+	cmd.WaitDelay = cmdWaitDelay
 	return cmd
 }
 
@@ -93,9 +115,9 @@ func (d *KwilCliDriver) SupportBatch() bool {
 	return false
 }
 
-func (d *KwilCliDriver) account(_ context.Context, acctID []byte) (*types.Account, error) {
-	cmd := d.newKwilCliCmd("account", "balance", hex.EncodeToString(acctID))
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) account(ctx context.Context, acctID []byte) (*types.Account, error) {
+	cmd := d.newKwilCliCmd(ctx, "account", "balance", hex.EncodeToString(acctID))
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account balance: %w", err)
 	}
@@ -119,8 +141,8 @@ func (d *KwilCliDriver) AccountBalance(ctx context.Context, acctID []byte) (*big
 }
 
 func (d *KwilCliDriver) TransferAmt(ctx context.Context, to []byte, amt *big.Int) (txHash []byte, err error) {
-	cmd := d.newKwilCliCmd("account", "transfer", hex.EncodeToString(to), amt.String())
-	out, err := mustRun(cmd, d.logger)
+	cmd := d.newKwilCliCmd(ctx, "account", "transfer", hex.EncodeToString(to), amt.String())
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to do acct transfer: %w", err)
 	}
@@ -133,12 +155,12 @@ func (d *KwilCliDriver) TransferAmt(ctx context.Context, to []byte, amt *big.Int
 }
 
 func (d *KwilCliDriver) DBID(name string) string {
-	return utils.GenerateDBID(name, d.identity)
+	return types.GenerateDBID(name, d.identity)
 }
 
-func (d *KwilCliDriver) listDatabase() ([]*types.DatasetIdentifier, error) {
-	cmd := d.newKwilCliCmd("database", "list", "--owner", hex.EncodeToString(d.identity))
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) listDatabase(ctx context.Context) ([]*types.DatasetIdentifier, error) {
+	cmd := d.newKwilCliCmd(ctx, "database", "list", "--owner", hex.EncodeToString(d.identity))
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list databases: %w", err)
 	}
@@ -152,15 +174,15 @@ func (d *KwilCliDriver) listDatabase() ([]*types.DatasetIdentifier, error) {
 	return dbs, nil
 }
 
-func (d *KwilCliDriver) DatabaseExists(_ context.Context, dbid string) error {
+func (d *KwilCliDriver) DatabaseExists(ctx context.Context, dbid string) error {
 	// check GetSchema
-	_, err := d.getSchema(dbid)
+	_, err := d.getSchema(ctx, dbid)
 	if err != nil {
 		return err
 	}
 
 	// check ListDatabases
-	dbs, err := d.listDatabase()
+	dbs, err := d.listDatabase(ctx)
 	if err != nil {
 		return err
 	}
@@ -180,7 +202,7 @@ func (d *KwilCliDriver) DatabaseExists(_ context.Context, dbid string) error {
 	return nil
 }
 
-func (d *KwilCliDriver) DeployDatabase(_ context.Context, db *types.Schema) (txHash []byte, err error) {
+func (d *KwilCliDriver) DeployDatabase(ctx context.Context, db *types.Schema) (txHash []byte, err error) {
 	schemaFile := path.Join(os.TempDir(), fmt.Sprintf("schema-%s.json", time.Now().Format("20060102150405")))
 
 	dbByte, err := json.MarshalIndent(db, "", "  ")
@@ -193,8 +215,8 @@ func (d *KwilCliDriver) DeployDatabase(_ context.Context, db *types.Schema) (txH
 		return nil, fmt.Errorf("failed to write database schema: %w", err)
 	}
 
-	cmd := d.newKwilCliCmd("database", "deploy", "-p", schemaFile, "-t", "json")
-	out, err := mustRun(cmd, d.logger)
+	cmd := d.newKwilCliCmd(ctx, "database", "deploy", "-p", schemaFile, "-t", "json")
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy database: %w", err)
 	}
@@ -207,9 +229,9 @@ func (d *KwilCliDriver) DeployDatabase(_ context.Context, db *types.Schema) (txH
 	return txHash, nil
 }
 
-func (d *KwilCliDriver) TxSuccess(_ context.Context, txHash []byte) error {
-	cmd := d.newKwilCliCmd("utils", "query-tx", hex.EncodeToString(txHash))
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) TxSuccess(ctx context.Context, txHash []byte) error {
+	cmd := d.newKwilCliCmd(ctx, "utils", "query-tx", hex.EncodeToString(txHash))
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return ErrTxNotConfirmed // not quite, but for this driver it's a retry condition
@@ -232,15 +254,12 @@ func (d *KwilCliDriver) TxSuccess(_ context.Context, txHash []byte) error {
 		return ErrTxNotConfirmed
 	}
 
-	if resp.TxResult.Code != 0 {
-		return fmt.Errorf("tx failed: %s", resp.TxResult.Log)
-	}
-	return nil
+	return resp.TxResult.Error()
 }
 
-func (d *KwilCliDriver) DropDatabase(_ context.Context, dbName string) (txHash []byte, err error) {
-	cmd := d.newKwilCliCmd("database", "drop", dbName)
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) DropDatabase(ctx context.Context, dbName string) (txHash []byte, err error) {
+	cmd := d.newKwilCliCmd(ctx, "database", "drop", dbName)
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to drop database: %w", err)
 	}
@@ -254,9 +273,9 @@ func (d *KwilCliDriver) DropDatabase(_ context.Context, dbName string) (txHash [
 	return txHash, nil
 }
 
-func (d *KwilCliDriver) getSchema(dbid string) (*types.Schema, error) {
-	cmd := d.newKwilCliCmd("database", "read-schema", "--dbid", dbid)
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) getSchema(ctx context.Context, dbid string) (*types.Schema, error) {
+	cmd := d.newKwilCliCmd(ctx, "database", "read-schema", "--dbid", dbid)
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to getSchema: %w", err)
 	}
@@ -271,8 +290,8 @@ func (d *KwilCliDriver) getSchema(dbid string) (*types.Schema, error) {
 
 // prepareCliActionParams returns the named action args for the given action name, in
 // the format of `name:value`
-func (d *KwilCliDriver) prepareCliActionParams(dbid string, actionName string, actionInputs []any) ([]string, error) {
-	schema, err := d.getSchema(dbid)
+func (d *KwilCliDriver) prepareCliActionParams(ctx context.Context, dbid string, actionName string, actionInputs []any) ([]string, error) {
+	schema, err := d.getSchema(ctx, dbid)
 	if err != nil {
 		return nil, err
 	}
@@ -339,13 +358,13 @@ func getParamList(schema *types.Schema, actionOrProcedure string) ([]string, err
 	return nil, fmt.Errorf("action/procedure not found: %s", actionOrProcedure)
 }
 
-func (d *KwilCliDriver) Execute(_ context.Context, dbid string, action string, inputs ...[]any) ([]byte, error) {
+func (d *KwilCliDriver) Execute(ctx context.Context, dbid string, action string, inputs ...[]any) ([]byte, error) {
 	if len(inputs) > 1 {
 		return nil, fmt.Errorf("kwil-cli does not support batched inputs")
 	}
 
 	// NOTE: kwil-cli does not support batched inputs
-	actionInputs, err := d.prepareCliActionParams(dbid, action, inputs[0])
+	actionInputs, err := d.prepareCliActionParams(ctx, dbid, action, inputs[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get action params: %w", err)
 	}
@@ -353,8 +372,8 @@ func (d *KwilCliDriver) Execute(_ context.Context, dbid string, action string, i
 	args := []string{"database", "execute", "--dbid", dbid, "--action", action}
 	args = append(args, actionInputs...)
 
-	cmd := d.newKwilCliCmd(args...)
-	out, err := mustRun(cmd, d.logger)
+	cmd := d.newKwilCliCmd(ctx, args...)
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute action: %w", err)
 	}
@@ -367,9 +386,9 @@ func (d *KwilCliDriver) Execute(_ context.Context, dbid string, action string, i
 	return txHash, nil
 }
 
-func (d *KwilCliDriver) QueryDatabase(_ context.Context, dbid, query string) (*clientType.Records, error) {
-	cmd := d.newKwilCliCmd("database", "query", "--dbid", dbid, query)
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) QueryDatabase(ctx context.Context, dbid, query string) (*clientType.Records, error) {
+	cmd := d.newKwilCliCmd(ctx, "database", "query", "--dbid", dbid, query)
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query database: %w", err)
 	}
@@ -382,9 +401,9 @@ func (d *KwilCliDriver) QueryDatabase(_ context.Context, dbid, query string) (*c
 	return records, nil
 }
 
-func (d *KwilCliDriver) Call(_ context.Context, dbid, action string, inputs []any) (*clientType.Records, error) {
+func (d *KwilCliDriver) Call(ctx context.Context, dbid, action string, inputs []any) (*clientType.Records, error) {
 	// NOTE: kwil-cli does not support batched inputs
-	actionInputs, err := d.prepareCliActionParams(dbid, action, inputs)
+	actionInputs, err := d.prepareCliActionParams(ctx, dbid, action, inputs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare action params: %w", err)
 	}
@@ -396,8 +415,8 @@ func (d *KwilCliDriver) Call(_ context.Context, dbid, action string, inputs []an
 		args = append(args, "--authenticate")
 	}
 
-	cmd := d.newKwilCliCmdWithYes(args...)
-	out, err := mustRunCallIgnorePrompt(cmd, d.logger)
+	cmd := d.newKwilCliCmdWithYes(ctx, args...)
+	out, err := mustRunCallIgnorePrompt(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call action: %w", err)
 	}
@@ -406,9 +425,9 @@ func (d *KwilCliDriver) Call(_ context.Context, dbid, action string, inputs []an
 	return parseRespQueryDb(out.Result)
 }
 
-func (d *KwilCliDriver) ChainInfo(_ context.Context) (*types.ChainInfo, error) {
-	cmd := d.newKwilCliCmd("utils", "chain-info")
-	out, err := mustRun(cmd, d.logger)
+func (d *KwilCliDriver) ChainInfo(ctx context.Context) (*types.ChainInfo, error) {
+	cmd := d.newKwilCliCmd(ctx, "utils", "chain-info")
+	out, err := mustRun(ctx, cmd, d.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain info: %w", err)
 	}
@@ -431,14 +450,18 @@ func (d *KwilCliDriver) ChainInfo(_ context.Context) (*types.ChainInfo, error) {
 
 ///////// helper functions
 
-// mustRun runs the give command, and parse stdout
-func mustRun(cmd *exec.Cmd, logger log.Logger) (*cliResponse, error) {
+// mustRun runs the give command, and parse stdout. If the command's context is
+// cancelled, the returned error wraps the context's error.
+func mustRun(ctx context.Context, cmd *exec.Cmd, logger log.Logger) (*cliResponse, error) {
 	cmd.Stderr = os.Stderr
 	//// here we capture the stdout
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
 	if err != nil {
+		if ctx.Err() != nil { // killed by the context, not a kwil-cli error
+			return nil, fmt.Errorf("%w: %v", ctx.Err(), err)
+		}
 		return nil, err
 	}
 
@@ -462,13 +485,16 @@ func mustRun(cmd *exec.Cmd, logger log.Logger) (*cliResponse, error) {
 // mustRunCallIgnorePrompt runs the given `kwil-cli database call` command, and
 // throw away the prompt output. This is necessary for authn call, because
 // kwil-cli will prompt for confirmation.
-func mustRunCallIgnorePrompt(cmd *exec.Cmd, logger log.Logger) (*cliResponse, error) {
+func mustRunCallIgnorePrompt(ctx context.Context, cmd *exec.Cmd, logger log.Logger) (*cliResponse, error) {
 	cmd.Stderr = os.Stderr
 	//// here we capture the stdout
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
 	if err != nil {
+		if ctx.Err() != nil { // killed by the context, not a kwil-cli error
+			return nil, fmt.Errorf("%w: %v", ctx.Err(), err)
+		}
 		return nil, err
 	}
 
@@ -538,11 +564,8 @@ func parseRespTxHash(data any) ([]byte, error) {
 
 // respTxQuery represents the tx query response(json) from the cli response
 type respTxQuery struct {
-	Height   int64 `json:"height"`
-	TxResult struct {
-		Code uint32 `json:"code"`
-		Log  string `json:"log"`
-	} `json:"tx_result"`
+	Height   int64                          `json:"height"`
+	TxResult transactions.TransactionResult `json:"tx_result"`
 }
 
 // parserRespTxQuery parses the tx query response(json) from the cli response
@@ -690,5 +713,46 @@ func (d *KwilCliDriver) Signer() []byte {
 }
 
 func (d *KwilCliDriver) Identifier() (string, error) {
-	return auth.EthSecp256k1Authenticator{}.Identifier(d.Signer())
+	signer, err := d.authSigner()
+	if err != nil {
+		return "", err
+	}
+	return auth.SignerIdentifier(signer)
+}
+
+// authSigner returns the auth.Signer for the driver's private key.
+func (d *KwilCliDriver) authSigner() (auth.Signer, error) {
+	if d.keyType() == "ed25519" {
+		key, err := crypto.Ed25519PrivateKeyFromHex(strings.TrimPrefix(d.privKey, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		return &auth.Ed25519Signer{Ed25519PrivateKey: *key}, nil
+	}
+	key, err := crypto.Secp256k1PrivateKeyFromHex(strings.TrimPrefix(d.privKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return &auth.EthPersonalSigner{Key: *key}, nil
+}
+
+// WithSigner returns a copy of the driver that uses the private key of the
+// given signer, sharing the rest of the configuration. This is cheaper than
+// constructing a new driver for each account in a test. The signer must be an
+// EthPersonalSigner or Ed25519Signer since kwil-cli needs the private key.
+func (d *KwilCliDriver) WithSigner(signer auth.Signer) (*KwilCliDriver, error) {
+	var privKey string
+	switch s := signer.(type) {
+	case *auth.EthPersonalSigner:
+		privKey = s.Key.Hex()
+	case *auth.Ed25519Signer:
+		privKey = s.Ed25519PrivateKey.Hex()
+	default:
+		return nil, fmt.Errorf("unsupported signer type %T", signer)
+	}
+
+	d2 := *d
+	d2.privKey = privKey
+	d2.identity = signer.Identity()
+	return &d2, nil
 }
@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kwilteam/kwil-db/core/crypto"
+	"github.com/kwilteam/kwil-db/core/crypto/auth"
+	"github.com/kwilteam/kwil-db/core/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKwilCliDriver_ContextCancel(t *testing.T) {
+	// A stand-in for kwil-cli that never returns on its own, except when
+	// reading a schema, which Call does before calling the action.
+	cliBin := filepath.Join(t.TempDir(), "kwil-cli")
+	script := `#!/bin/sh
+if [ "$2" = "read-schema" ]; then
+	echo '{"result":{"name":"db","actions":[{"name":"act","parameters":[]}]}}'
+	exit 0
+fi
+exec sleep 30
+`
+	err := os.WriteFile(cliBin, []byte(script), 0755)
+	require.NoError(t, err)
+
+	d := NewKwilCliDriver(cliBin, "http://127.0.0.1:8484", "", "kwil-test-chain", nil, false, nil, log.NewNoOp())
+
+	t.Run("cancelled before", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := d.ChainInfo(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancelled while running", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := d.ChainInfo(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+
+	// Call runs kwil-cli in a `yes |` pipeline, which must be killed as a
+	// whole for the command to return.
+	t.Run("call cancelled while running", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := d.Call(ctx, "xdbid", "act", nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 3*time.Second)
+	})
+}
+
+func TestKwilCliDriver_WithSigner(t *testing.T) {
+	secpKey, err := crypto.Secp256k1PrivateKeyFromHex("f1aa5a7966c3863ccde3047f6a1e266cdc0c76b399e256b8fede92b1c69e4f4e")
+	require.NoError(t, err)
+	secpSigner := &auth.EthPersonalSigner{Key: *secpKey}
+
+	edKey, err := crypto.GenerateEd25519Key()
+	require.NoError(t, err)
+	edSigner := &auth.Ed25519Signer{Ed25519PrivateKey: *edKey}
+
+	d := NewKwilCliDriver("kwil-cli", "http://127.0.0.1:8484", secpKey.Hex(), "kwil-test-chain",
+		secpSigner.Identity(), false, nil, log.NewNoOp())
+	ident, err := d.Identifier()
+	require.NoError(t, err)
+	wantIdent, err := auth.SignerIdentifier(secpSigner)
+	require.NoError(t, err)
+	assert.Equal(t, wantIdent, ident)
+
+	d2, err := d.WithSigner(edSigner)
+	require.NoError(t, err)
+	assert.Equal(t, "ed25519", d2.keyType())
+	assert.Equal(t, edSigner.Identity(), d2.Signer())
+	ident2, err := d2.Identifier()
+	require.NoError(t, err)
+	wantIdent2, err := auth.SignerIdentifier(edSigner)
+	require.NoError(t, err)
+	assert.Equal(t, wantIdent2, ident2)
+	assert.Equal(t, d.rpcURL, d2.rpcURL)
+	assert.Equal(t, d.chainID, d2.chainID)
+
+	// The original driver is unchanged.
+	assert.Equal(t, "secp256k1", d.keyType())
+	ident, err = d.Identifier()
+	require.NoError(t, err)
+	assert.Equal(t, wantIdent, ident)
+}
@@ -15,8 +15,6 @@ import (
 	rpcclient "github.com/kwilteam/kwil-db/core/rpc/client"
 	"github.com/kwilteam/kwil-db/core/types"
 	clientType "github.com/kwilteam/kwil-db/core/types/client"
-	"github.com/kwilteam/kwil-db/core/types/transactions"
-	"github.com/kwilteam/kwil-db/core/utils"
 	ethdeployer "github.com/kwilteam/kwil-db/test/integration/eth-deployer"
 	"go.uber.org/zap"
 )
@@ -74,8 +72,8 @@ func (d *KwildClientDriver) TxSuccess(ctx context.Context, txHash []byte) error
 		zap.String("txHash", hex.EncodeToString(txHash)),
 		zap.Any("result", resp.TxResult))
 
-	if resp.TxResult.Code != transactions.CodeOk.Uint32() {
-		return fmt.Errorf("transaction not ok, %s", resp.TxResult.Log)
+	if err = resp.TxResult.Error(); err != nil {
+		return err
 	}
 
 	// NOTE: THIS should not be considered a failure, should retry
@@ -99,7 +97,7 @@ func (d *KwildClientDriver) TransferAmt(ctx context.Context, to []byte, amt *big
 }
 
 func (d *KwildClientDriver) DBID(name string) string {
-	return utils.GenerateDBID(name, d.signer.Identity())
+	return types.GenerateDBID(name, d.signer.Identity())
 }
 
 func (d *KwildClientDriver) DeployDatabase(ctx context.Context, db *types.Schema) ([]byte, error) {
@@ -225,5 +223,5 @@ func (d *KwildClientDriver) Signer() []byte {
 }
 
 func (d *KwildClientDriver) Identifier() (string, error) {
-	return auth.EthSecp256k1Authenticator{}.Identifier(d.Signer())
+	return auth.SignerIdentifier(d.signer)
 }
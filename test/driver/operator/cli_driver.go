@@ -5,10 +5,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/kwilteam/kwil-db/cmd/common/display"
 	"github.com/kwilteam/kwil-db/core/types"
+	"github.com/kwilteam/kwil-db/core/types/transactions"
 	"github.com/kwilteam/kwil-db/test/driver"
 )
 
@@ -67,10 +67,7 @@ func (o *OperatorCLIDriver) TxSuccess(ctx context.Context, txHash []byte) error
 		return driver.ErrTxNotConfirmed
 	}
 
-	if res.TxResult.Code != 0 {
-		return fmt.Errorf("tx failed: %s", res.TxResult.Log)
-	}
-	return nil
+	return res.TxResult.Error()
 }
 
 func (o *OperatorCLIDriver) ValidatorJoinStatus(ctx context.Context, pubKey []byte) (*types.JoinRequest, error) {
@@ -141,9 +138,6 @@ type cliResponse struct {
 
 // respTxQuery represents the tx query response(json) from the cli response
 type respTxQuery struct {
-	Height   int64 `json:"height"`
-	TxResult struct {
-		Code uint32 `json:"code"`
-		Log  string `json:"log"`
-	} `json:"tx_result"`
+	Height   int64                          `json:"height"`
+	TxResult transactions.TransactionResult `json:"tx_result"`
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/kwilteam/kwil-db/core/adminclient"
 	"github.com/kwilteam/kwil-db/core/types"
-	"github.com/kwilteam/kwil-db/core/types/transactions"
 	"github.com/kwilteam/kwil-db/test/driver"
 )
 
@@ -22,8 +21,8 @@ func (a *AdminClientDriver) TxSuccess(ctx context.Context, txHash []byte) error
 		return fmt.Errorf("failed to query: %w", err)
 	}
 
-	if resp.TxResult.Code != transactions.CodeOk.Uint32() {
-		return fmt.Errorf("transaction not ok, %s", resp.TxResult.Log)
+	if err = resp.TxResult.Error(); err != nil {
+		return err
 	}
 
 	// NOTE: THIS should not be considered a failure, should retry
@@ -98,11 +98,11 @@ func (tc *timedClient) Ping(ctx context.Context) (string, error) {
 	return tc.Client.Ping(ctx)
 }
 
-func (tc *timedClient) Query(ctx context.Context, dbid string, query string) (*clientType.Records, error) {
+func (tc *timedClient) Query(ctx context.Context, dbid string, query string, opts ...clientType.QueryOpt) (*clientType.Records, error) {
 	if tc.showReqDur {
 		defer tc.printDur(time.Now(), "Query")
 	}
-	return tc.Client.Query(ctx, dbid, query)
+	return tc.Client.Query(ctx, dbid, query, opts...)
 }
 
 func (tc *timedClient) TxQuery(ctx context.Context, txHash []byte) (*transactions.TcTxQueryResponse, error) {
@@ -119,6 +119,13 @@ func (tc *timedClient) WaitTx(ctx context.Context, txHash []byte, interval time.
 	return tc.Client.WaitTx(ctx, txHash, interval)
 }
 
+func (tc *timedClient) WaitConfirmations(ctx context.Context, txHash []byte, confirmations int64, interval time.Duration) (*transactions.TcTxQueryResponse, error) {
+	if tc.showReqDur {
+		defer tc.printDur(time.Now(), "WaitConfirmations")
+	}
+	return tc.Client.WaitConfirmations(ctx, txHash, confirmations, interval)
+}
+
 func (tc *timedClient) Transfer(ctx context.Context, to []byte, amount *big.Int, opts ...clientType.TxOpt) (transactions.TxHash, error) {
 	if tc.showReqDur {
 		defer tc.printDur(time.Now(), "Transfer")